@@ -119,12 +119,17 @@ func AuthRefresh(collection string) error {
 	return mustDefault().AuthRefresh(collection)
 }
 
-// AdminRefresh refreshes the admin/superuser token.
+// AdminRefresh refreshes the admin/superuser token, using the endpoint that
+// matches the detected server API.
 func (c *Client) AdminRefresh() error {
+	endpoint := "/api/admins/auth-refresh"
+	if v, _ := c.ServerAPI(); v == ServerAPISuperusers {
+		endpoint = "/api/collections/_superusers/auth-refresh"
+	}
 	var out authResponse
 	err := c.doJSON(
 		http.MethodPost,
-		"/api/admins/auth-refresh",
+		endpoint,
 		"",
 		nil,
 		&out,
@@ -416,11 +421,18 @@ func (c *Client) LoginSuperAdmin(email, password string) error {
 	return nil
 }
 
-// LoginAdmin authenticates with the superusers endpoint first and falls back
-// to the legacy admins endpoint.
+// LoginAdmin authenticates as an admin/superuser. It detects the server API up
+// front and uses the _superusers collection on v0.23+ or /api/admins on older
+// servers. If detection fails it tries superusers first and falls back to the
+// legacy admins endpoint.
 func (c *Client) LoginAdmin(email, password string) error {
-	if err := c.LoginSuperAdmin(email, password); err == nil {
-		return nil
+	switch v, _ := c.ServerAPI(); v {
+	case ServerAPISuperusers:
+		return c.LoginSuperAdmin(email, password)
+	case ServerAPIUnknown:
+		if err := c.LoginSuperAdmin(email, password); err == nil {
+			return nil
+		}
 	}
 	var out authResponse
 	err := c.doJSON(
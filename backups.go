@@ -0,0 +1,134 @@
+package pbclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BackupInfo describes a backup file stored by PocketBase.
+type BackupInfo struct {
+	Key      string `json:"key"`
+	Size     int64  `json:"size"`
+	Modified string `json:"modified"`
+}
+
+// BackupsService manages /api/backups. All operations require superuser auth.
+type BackupsService struct {
+	c *Client
+
+	// RestoreTimeout bounds how long Restore waits for the server to come
+	// back. Defaults to 60s.
+	RestoreTimeout time.Duration
+}
+
+// Backups returns the backups service.
+func (c *Client) Backups() *BackupsService { return &BackupsService{c: c} }
+
+// List returns all stored backups.
+func (s *BackupsService) List() ([]BackupInfo, error) {
+	var out []BackupInfo
+	err := s.c.doJSON(http.MethodGet, "/api/backups", "", nil, &out)
+	return out, err
+}
+
+// Create starts a new backup. An empty name lets PocketBase generate one;
+// otherwise it must end in ".zip".
+func (s *BackupsService) Create(name string) error {
+	body := map[string]any{}
+	if name != "" {
+		body["name"] = name
+	}
+	return s.c.doJSON(http.MethodPost, "/api/backups", "", body, nil)
+}
+
+// Upload streams a backup zip to the server under the given file name.
+func (s *BackupsService) Upload(name string, r io.Reader) error {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		err := writeFilePart(mw, "file", File{Name: name, ContentType: "application/zip", Reader: r})
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	resp, err := s.c.do(&request{method: http.MethodPost, endpoint: "/api/backups/upload", stream: pr, contentType: mw.FormDataContentType()})
+	_ = pr.Close()
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Download writes the named backup to w using a short-lived file token.
+func (s *BackupsService) Download(ctx context.Context, name string, w io.Writer) error {
+	token, err := s.c.FileToken()
+	if err != nil {
+		return err
+	}
+	resp, err := s.c.do(&request{
+		ctx:      ctx,
+		method:   http.MethodGet,
+		endpoint: "/api/backups/" + url.PathEscape(name),
+		query:    "token=" + url.QueryEscape(token),
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// Restore restores the named backup and waits until the restarted server
+// passes its health check again.
+//
+// PocketBase extracts the backup before restarting, so the primary is polled
+// until it stops answering (or restoreDownWait elapses) and then until it is
+// healthy again, all within RestoreTimeout.
+func (s *BackupsService) Restore(name string) error {
+	if err := s.c.doJSON(http.MethodPost, "/api/backups/"+url.PathEscape(name)+"/restore", "", nil, nil); err != nil {
+		return err
+	}
+	timeout := s.RestoreTimeout
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	downBy := time.Now().Add(restoreDownWait)
+	if downBy.After(deadline) {
+		downBy = deadline
+	}
+	for time.Now().Before(downBy) && s.c.healthAt(s.c.baseURL) == nil {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	backoff := 100 * time.Millisecond
+	for s.c.healthAt(s.c.baseURL) != nil {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("pbclient: restore %s: server not ready within %s", name, timeout)
+		}
+		time.Sleep(backoff)
+		if backoff < 750*time.Millisecond {
+			backoff *= 2
+		}
+	}
+	s.c.InvalidateCache()
+	return nil
+}
+
+// restoreDownWait bounds how long Restore waits for the old process to stop
+// before assuming the restart already happened.
+const restoreDownWait = 15 * time.Second
+
+// Delete removes the named backup.
+func (s *BackupsService) Delete(name string) error {
+	return s.c.doJSON(http.MethodDelete, "/api/backups/"+url.PathEscape(name), "", nil, nil)
+}
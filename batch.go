@@ -1,9 +1,13 @@
 package pbclient
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
 // Batch endpoint runs all sub-requests under the same auth context as the outer /api/batch request.
@@ -13,6 +17,10 @@ type BatchRequest struct {
 	URL     string            `json:"url"`
 	Headers map[string]string `json:"headers,omitempty"`
 	Body    any               `json:"body,omitempty"`
+
+	// Files are uploaded as multipart fields "requests.N.<field>". Any request
+	// with files switches the whole /api/batch call to multipart/form-data.
+	Files map[string][]File `json:"-"`
 }
 
 // BatchPayload is the request body sent to /api/batch.
@@ -30,6 +38,7 @@ type BatchResponse struct {
 type Batch struct {
 	c        *Client
 	requests []BatchRequest
+	results  []batchSink
 }
 
 // NewBatch creates a new batch bound to the provided client or the default client.
@@ -41,7 +50,10 @@ func NewBatch(params ...*Client) *Batch {
 }
 
 // Reset clears queued requests.
-func (b *Batch) Reset() { b.requests = b.requests[:0] }
+func (b *Batch) Reset() {
+	b.requests = b.requests[:0]
+	b.results = b.results[:0]
+}
 
 // BatchCollection provides collection helpers that enqueue batch requests.
 type BatchCollection struct {
@@ -53,46 +65,300 @@ type BatchCollection struct {
 func (b *Batch) Collection(name string) *BatchCollection { return &BatchCollection{b: b, name: name} }
 
 // Create enqueues a record create operation.
-func (bc *BatchCollection) Create(body any, params ...string) {
-	bc.b.add(http.MethodPost, "/api/collections/"+url.PathEscape(bc.name)+"/records", body, params...)
+func (bc *BatchCollection) Create(body any, params ...string) *BatchResult[map[string]any] {
+	return BatchCollectionOf[map[string]any](bc.b, bc.name).Create(body, params...)
 }
 
 // Update enqueues a record update operation.
-func (bc *BatchCollection) Update(id string, body any, params ...string) {
-	bc.b.add(http.MethodPatch, "/api/collections/"+url.PathEscape(bc.name)+"/records/"+url.PathEscape(id), body, params...)
+func (bc *BatchCollection) Update(id string, body any, params ...string) *BatchResult[map[string]any] {
+	return BatchCollectionOf[map[string]any](bc.b, bc.name).Update(id, body, params...)
 }
 
 // Upsert enqueues a collection upsert operation.
-func (bc *BatchCollection) Upsert(body any, params ...string) {
-	bc.b.add(http.MethodPut, "/api/collections/"+url.PathEscape(bc.name)+"/records", body, params...)
+func (bc *BatchCollection) Upsert(body any, params ...string) *BatchResult[map[string]any] {
+	return BatchCollectionOf[map[string]any](bc.b, bc.name).Upsert(body, params...)
 }
 
 // Delete enqueues a record delete operation.
-func (bc *BatchCollection) Delete(id string, params ...string) {
-	bc.b.add(http.MethodDelete, "/api/collections/"+url.PathEscape(bc.name)+"/records/"+url.PathEscape(id), nil, params...)
+func (bc *BatchCollection) Delete(id string, params ...string) *BatchResult[struct{}] {
+	return BatchCollectionOf[map[string]any](bc.b, bc.name).Delete(id, params...)
+}
+
+// TypedBatchCollection enqueues batch operations whose results decode into T.
+type TypedBatchCollection[T any] struct {
+	b     *Batch
+	name  string
+	query string
+}
+
+// BatchCollectionOf returns typed batch helpers for a collection name.
+func BatchCollectionOf[T any](b *Batch, name string) *TypedBatchCollection[T] {
+	return &TypedBatchCollection[T]{b: b, name: name}
+}
+
+// params prepends the default query to the optional per-call params.
+func (bc *TypedBatchCollection[T]) params(p []string) []string {
+	return []string{mergeQuery(bc.query, optParam(p))}
+}
+
+// Create enqueues a record create operation.
+func (bc *TypedBatchCollection[T]) Create(body any, params ...string) *BatchResult[T] {
+	r := &BatchResult[T]{}
+	bc.b.add(r, http.MethodPost, "/api/collections/"+url.PathEscape(bc.name)+"/records", body, bc.params(params)...)
+	return r
+}
+
+// Update enqueues a record update operation.
+func (bc *TypedBatchCollection[T]) Update(id string, body any, params ...string) *BatchResult[T] {
+	r := &BatchResult[T]{}
+	bc.b.add(r, http.MethodPatch, "/api/collections/"+url.PathEscape(bc.name)+"/records/"+url.PathEscape(id), body, bc.params(params)...)
+	return r
+}
+
+// Upsert enqueues a collection upsert operation.
+func (bc *TypedBatchCollection[T]) Upsert(body any, params ...string) *BatchResult[T] {
+	r := &BatchResult[T]{}
+	bc.b.add(r, http.MethodPut, "/api/collections/"+url.PathEscape(bc.name)+"/records", body, bc.params(params)...)
+	return r
+}
+
+// Delete enqueues a record delete operation.
+func (bc *TypedBatchCollection[T]) Delete(id string, params ...string) *BatchResult[struct{}] {
+	r := &BatchResult[struct{}]{}
+	bc.b.add(r, http.MethodDelete, "/api/collections/"+url.PathEscape(bc.name)+"/records/"+url.PathEscape(id), nil, bc.params(params)...)
+	return r
 }
 
 // Raw enqueues an arbitrary request for /api/batch.
-func (b *Batch) Raw(method, urlPath string, body any) {
-	b.requests = append(b.requests, BatchRequest{Method: method, URL: urlPath, Body: body})
+func (b *Batch) Raw(method, urlPath string, body any) *BatchResult[json.RawMessage] {
+	r := &BatchResult[json.RawMessage]{}
+	body, files := splitFiles(body)
+	b.requests = append(b.requests, BatchRequest{Method: method, URL: urlPath, Body: body, Files: files})
+	b.results = append(b.results, r)
+	return r
 }
 
-func (b *Batch) add(method, basePath string, body any, params ...string) {
+// add enqueues a request. File, *File and []File values in a map body are
+// moved to the request's Files for multipart upload.
+func (b *Batch) add(sink batchSink, method, basePath string, body any, params ...string) {
 	u := basePath
 	q := optParam(params)
 	if q != "" {
 		u += "?" + q
 	}
-	b.requests = append(b.requests, BatchRequest{Method: method, URL: u, Body: body})
+	body, files := splitFiles(body)
+	b.requests = append(b.requests, BatchRequest{Method: method, URL: u, Body: body, Files: files})
+	b.results = append(b.results, sink)
+}
+
+// splitFiles separates file values from a map body. Other bodies are returned
+// unchanged.
+func splitFiles(body any) (any, map[string][]File) {
+	m, ok := body.(map[string]any)
+	if !ok {
+		return body, nil
+	}
+	var files map[string][]File
+	var rest map[string]any
+	for k, v := range m {
+		var fs []File
+		switch f := v.(type) {
+		case File:
+			fs = []File{f}
+		case *File:
+			fs = []File{*f}
+		case []File:
+			fs = f
+		default:
+			continue
+		}
+		if files == nil {
+			files = map[string][]File{}
+			rest = make(map[string]any, len(m))
+			for k2, v2 := range m {
+				rest[k2] = v2
+			}
+		}
+		files[k] = fs
+		delete(rest, k)
+	}
+	if files == nil {
+		return body, nil
+	}
+	return rest, files
 }
 
 // Send posts all queued requests to /api/batch and returns sub-responses.
+// The handles returned when enqueuing are populated as well, including when
+// the batch fails.
 func (b *Batch) Send(params ...string) ([]BatchResponse, error) {
+	return b.post(b.c.ctx, optParam(params), b.requests, b.results)
+}
+
+// post sends one /api/batch call for reqs and resolves the matching sinks.
+func (b *Batch) post(ctx context.Context, query string, reqs []BatchRequest, sinks []batchSink) ([]BatchResponse, error) {
 	var out []BatchResponse
-	payload := BatchPayload{Requests: b.requests}
-	err := b.c.doJSON(http.MethodPost, "/api/batch", optParam(params), payload, &out)
+	r := &request{ctx: ctx, method: http.MethodPost, endpoint: "/api/batch", query: query, body: BatchPayload{Requests: reqs}}
+	if files := batchFiles(reqs); len(files) > 0 {
+		payload, contentType, err := encodeMultipart(r.body, files)
+		if err != nil {
+			return nil, err
+		}
+		r.payload, r.contentType = payload, contentType
+	}
+	body, err := b.c.fetch(r)
+	if err == nil {
+		err = json.Unmarshal(body, &out)
+	}
+	if b.c.cache != nil {
+		b.c.cache.invalidateBatch(reqs)
+	}
+	resolveBatch(sinks, out, err)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
+
+// batchFiles collects the files of reqs keyed by their multipart field name.
+func batchFiles(reqs []BatchRequest) map[string][]File {
+	var out map[string][]File
+	for i, r := range reqs {
+		for field, fs := range r.Files {
+			if out == nil {
+				out = map[string][]File{}
+			}
+			out["requests."+strconv.Itoa(i)+"."+field] = fs
+		}
+	}
+	return out
+}
+
+// resolveBatch populates result handles from a batch response or failure.
+// When PocketBase rejects the transaction it reports the failing sub-requests
+// in data.requests; those handles get their own error and the rest get err.
+func resolveBatch(sinks []batchSink, out []BatchResponse, err error) {
+	if err == nil {
+		for i, sink := range sinks {
+			if i < len(out) {
+				sink.resolve(out[i].Status, out[i].Body, nil)
+			} else {
+				sink.resolve(0, nil, errBatchMissingResponse)
+			}
+		}
+		return
+	}
+
+	itemErrs := batchItemErrors(err)
+	for i, sink := range sinks {
+		if ie, ok := itemErrs[strconv.Itoa(i)]; ok {
+			sink.resolve(ie.Status, ie.Body, ie)
+			continue
+		}
+		sink.resolve(0, nil, err)
+	}
+}
+
+// batchItemErrors extracts per-request errors from a failed batch response.
+func batchItemErrors(err error) map[string]*APIError {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+	var parsed struct {
+		Data struct {
+			Requests map[string]struct {
+				Code     string          `json:"code"`
+				Message  string          `json:"message"`
+				Response json.RawMessage `json:"response"`
+			} `json:"requests"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(apiErr.Body, &parsed) != nil {
+		return nil
+	}
+	out := make(map[string]*APIError, len(parsed.Data.Requests))
+	for idx, r := range parsed.Data.Requests {
+		// response is the sub-request result, {"status": ..., "body": {...}},
+		// whose body carries the message and field errors.
+		status, body := apiErr.Status, json.RawMessage(r.Response)
+		var sub struct {
+			Status int             `json:"status"`
+			Body   json.RawMessage `json:"body"`
+		}
+		if json.Unmarshal(r.Response, &sub) == nil {
+			if sub.Status != 0 {
+				status = sub.Status
+			}
+			if len(sub.Body) > 0 {
+				body = sub.Body
+			}
+		}
+		ie := parseAPIError(status, body)
+		if ie.Message == "" {
+			ie.Message = r.Message
+		}
+		out[idx] = ie
+	}
+	return out
+}
+
+var errBatchMissingResponse = errors.New("pbclient: batch response missing for request")
+
+// ErrBatchNotSent is returned by BatchResult accessors before Batch.Send.
+var ErrBatchNotSent = errors.New("pbclient: batch not sent")
+
+type batchSink interface {
+	resolve(status int, body json.RawMessage, err error)
+}
+
+// BatchResult is the outcome of one queued batch operation. It is populated
+// by Batch.Send.
+type BatchResult[T any] struct {
+	done   bool
+	status int
+	body   json.RawMessage
+	record T
+	err    error
+}
+
+func (r *BatchResult[T]) resolve(status int, body json.RawMessage, err error) {
+	r.done, r.status, r.body = true, status, body
+	switch {
+	case err != nil:
+		r.err = err
+	case status >= 400:
+		r.err = parseAPIError(status, body)
+	case len(body) > 0:
+		if e := json.Unmarshal(body, &r.record); e != nil {
+			r.err = fmt.Errorf("pbclient: decode batch result: %w", e)
+		}
+	}
+}
+
+// Result returns the decoded record, or the per-operation error. A failing
+// operation yields an *APIError.
+func (r *BatchResult[T]) Result() (T, error) {
+	if !r.done {
+		var zero T
+		return zero, ErrBatchNotSent
+	}
+	return r.record, r.err
+}
+
+// Err returns the per-operation error, if any.
+func (r *BatchResult[T]) Err() error {
+	if !r.done {
+		return ErrBatchNotSent
+	}
+	return r.err
+}
+
+// Status returns the sub-response HTTP status, or 0 if unknown.
+func (r *BatchResult[T]) Status() int { return r.status }
+
+// Raw returns the undecoded sub-response body.
+func (r *BatchResult[T]) Raw() json.RawMessage { return r.body }
+
+// Done reports whether the batch has been sent.
+func (r *BatchResult[T]) Done() bool { return r.done }
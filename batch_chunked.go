@@ -0,0 +1,208 @@
+package pbclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ChunkOptions configures Batch.SendChunked.
+type ChunkOptions struct {
+	// Size is the maximum number of sub-requests per chunk. Defaults to 50,
+	// the PocketBase default, unless FromSettings is set.
+	Size int
+	// MaxBodySize caps the estimated size of a chunk in bytes. Zero means no
+	// size-based splitting unless FromSettings provides a limit. Attached
+	// files count when their size is known: readers with a Len method (such
+	// as *bytes.Reader) or that implement io.Seeker (such as *os.File).
+	// Other readers are not counted.
+	MaxBodySize int64
+	// FromSettings reads batch.maxRequests and batch.maxBodySize from the
+	// server settings for limits left unset. It requires superuser auth; when
+	// the settings cannot be read the defaults are used.
+	FromSettings bool
+	// Concurrency is the number of chunks in flight. Defaults to 1, which
+	// sends chunks in order and stops at the first failure.
+	Concurrency int
+	// Query is an optional raw query string for each /api/batch call.
+	Query string
+}
+
+// BatchChunkError reports a failed chunk in SendChunked.
+type BatchChunkError struct {
+	Chunk  int // zero-based chunk index
+	Offset int // index of the chunk's first request in the batch
+	Count  int // number of requests in the chunk
+	Err    error
+}
+
+func (e *BatchChunkError) Error() string {
+	return fmt.Sprintf("pbclient: batch chunk %d (requests %d-%d): %v", e.Chunk, e.Offset, e.Offset+e.Count-1, e.Err)
+}
+
+func (e *BatchChunkError) Unwrap() error { return e.Err }
+
+type batchChunk struct {
+	index, offset, count int
+}
+
+// SendChunked sends the queued requests as several /api/batch calls that fit
+// the server limits.
+//
+// Each chunk is its own transaction: the batch as a whole is NOT atomic, and
+// chunks sent before a failure stay committed. The returned responses cover
+// every request in order; entries of chunks that failed or were not sent are
+// zero. After a failure no further chunks are sent, while chunks already in
+// flight complete normally. The error, if any, is a *BatchChunkError for the
+// lowest failed chunk. Result handles of unsent chunks report the same error.
+func (b *Batch) SendChunked(ctx context.Context, opts ChunkOptions) ([]BatchResponse, error) {
+	if ctx == nil {
+		ctx = b.c.ctx
+	}
+	size, maxBody := opts.Size, opts.MaxBodySize
+	if opts.FromSettings && (size <= 0 || maxBody <= 0) {
+		if st, err := b.c.Settings().Get(); err == nil && st.Batch != nil {
+			if size <= 0 {
+				size = st.Batch.MaxRequests
+			}
+			if maxBody <= 0 {
+				maxBody = st.Batch.MaxBodySize
+			}
+		}
+	}
+	if size <= 0 {
+		size = 50
+	}
+
+	chunks := b.chunks(size, maxBody)
+	out := make([]BatchResponse, len(b.requests))
+
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = 1
+	}
+	var (
+		mu       sync.Mutex
+		stopped  bool
+		firstErr *BatchChunkError
+		unsent   []batchChunk
+		wg       sync.WaitGroup
+	)
+	// skip reports whether ch must not be sent because an earlier chunk failed
+	// or ctx is done, and records it as unsent.
+	skip := func(ch batchChunk) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped || ctx.Err() != nil {
+			unsent = append(unsent, ch)
+			return true
+		}
+		return false
+	}
+	next := make(chan batchChunk)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ch := range next {
+				if skip(ch) {
+					continue
+				}
+				// In-flight chunks run to completion under the caller's ctx so
+				// a failure elsewhere cannot abort a chunk the server may
+				// already have committed.
+				res, err := b.post(ctx, joinQuery(opts.Query), b.requests[ch.offset:ch.offset+ch.count], b.results[ch.offset:ch.offset+ch.count])
+				if err != nil {
+					mu.Lock()
+					stopped = true
+					if firstErr == nil || ch.index < firstErr.Chunk {
+						firstErr = &BatchChunkError{Chunk: ch.index, Offset: ch.offset, Count: ch.count, Err: err}
+					}
+					mu.Unlock()
+					continue
+				}
+				copy(out[ch.offset:ch.offset+ch.count], res)
+			}
+		}()
+	}
+	for _, ch := range chunks {
+		if skip(ch) {
+			continue
+		}
+		next <- ch
+	}
+	close(next)
+	wg.Wait()
+
+	if firstErr == nil && len(unsent) > 0 {
+		// Only ctx stops sending without a failed chunk.
+		first := unsent[0]
+		for _, ch := range unsent[1:] {
+			if ch.index < first.index {
+				first = ch
+			}
+		}
+		firstErr = &BatchChunkError{Chunk: first.index, Offset: first.offset, Count: first.count, Err: ctx.Err()}
+	}
+	for _, ch := range unsent {
+		resolveBatch(b.results[ch.offset:ch.offset+ch.count], nil, firstErr)
+	}
+
+	if firstErr != nil {
+		return out, firstErr
+	}
+	return out, nil
+}
+
+// chunks splits the queued requests by count and, when maxBody > 0, by the
+// estimated encoded size of each chunk.
+func (b *Batch) chunks(size int, maxBody int64) []batchChunk {
+	var out []batchChunk
+	start := 0
+	var bytes int64
+	for i, r := range b.requests {
+		n := int64(0)
+		if maxBody > 0 {
+			enc, _ := json.Marshal(r)
+			n = int64(len(enc)) + 1
+			for field, fs := range r.Files {
+				for _, f := range fs {
+					// Part headers are small; 128 bytes covers the boundary,
+					// disposition and content type lines.
+					n += fileSize(f.Reader) + int64(len(field)+len(f.Name)) + 128
+				}
+			}
+		}
+		count := i - start
+		if count > 0 && (count >= size || (maxBody > 0 && bytes+n > maxBody-32)) {
+			out = append(out, batchChunk{index: len(out), offset: start, count: count})
+			start, bytes = i, 0
+		}
+		bytes += n
+	}
+	if start < len(b.requests) {
+		out = append(out, batchChunk{index: len(out), offset: start, count: len(b.requests) - start})
+	}
+	return out
+}
+
+// fileSize returns the number of bytes left in r, or 0 when it is unknown.
+func fileSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if _, err2 := v.Seek(cur, io.SeekStart); err != nil || err2 != nil {
+			return 0
+		}
+		return end - cur
+	}
+	return 0
+}
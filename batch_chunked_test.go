@@ -0,0 +1,82 @@
+package pbclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestSendChunkedKeepsInFlightChunks checks that a failing chunk neither
+// aborts a slower chunk already in flight nor hides its own error behind it.
+func TestSendChunkedKeepsInFlightChunks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Requests []struct {
+				Body struct {
+					N int `json:"n"`
+				} `json:"body"`
+			} `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || len(payload.Requests) != 1 {
+			http.Error(w, "bad payload", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch payload.Requests[0].Body.N {
+		case 0:
+			time.Sleep(150 * time.Millisecond)
+		case 1:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":400,"message":"Batch transaction failed.","data":{}}`))
+			return
+		}
+		w.Write([]byte(`[{"status":200,"body":{"ok":true}}]`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewBatch(c)
+	var handles []*BatchResult[json.RawMessage]
+	for i := 0; i < 4; i++ {
+		handles = append(handles, b.Raw(http.MethodPost, "/api/collections/posts/records", map[string]any{"n": i}))
+	}
+
+	out, err := b.SendChunked(context.Background(), ChunkOptions{Size: 1, Concurrency: 2})
+
+	var chunkErr *BatchChunkError
+	if !errors.As(err, &chunkErr) {
+		t.Fatalf("err = %v, want *BatchChunkError", err)
+	}
+	if chunkErr.Chunk != 1 {
+		t.Fatalf("failed chunk = %d, want 1 (%v)", chunkErr.Chunk, err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest {
+		t.Fatalf("err = %v, want the chunk's 400", err)
+	}
+
+	if err := handles[0].Err(); err != nil {
+		t.Fatalf("in-flight chunk 0 failed: %v", err)
+	}
+	if out[0].Status != http.StatusOK {
+		t.Fatalf("out[0].Status = %d, want 200", out[0].Status)
+	}
+	if handles[1].Err() == nil {
+		t.Fatal("chunk 1 handle has no error")
+	}
+	for i := 2; i < 4; i++ {
+		if err := handles[i].Err(); !errors.Is(err, chunkErr) {
+			t.Fatalf("unsent chunk %d handle err = %v, want %v", i, err, chunkErr)
+		}
+		if out[i].Status != 0 {
+			t.Fatalf("unsent chunk %d has a response", i)
+		}
+	}
+}
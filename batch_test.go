@@ -0,0 +1,69 @@
+package pbclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBatchItemErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{
+			"status": 400,
+			"message": "Batch transaction failed.",
+			"data": {
+				"requests": {
+					"1": {
+						"code": "batch_request_failed",
+						"message": "Batch request failed.",
+						"response": {
+							"status": 400,
+							"body": {
+								"status": 400,
+								"message": "Failed to create record.",
+								"data": {"title": {"code": "validation_required", "message": "Cannot be blank."}}
+							}
+						}
+					}
+				}
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewBatch(c)
+	ok := b.Collection("posts").Create(map[string]any{"title": "a"})
+	bad := b.Collection("posts").Create(map[string]any{"title": ""})
+
+	if _, err := b.Send(); err == nil {
+		t.Fatal("Send succeeded, want the batch error")
+	}
+
+	var itemErr *APIError
+	if !errors.As(bad.Err(), &itemErr) {
+		t.Fatalf("failed item err = %v, want *APIError", bad.Err())
+	}
+	if itemErr.Status != http.StatusBadRequest {
+		t.Fatalf("Status = %d, want 400", itemErr.Status)
+	}
+	if itemErr.Message != "Failed to create record." {
+		t.Fatalf("Message = %q, want the sub-request message", itemErr.Message)
+	}
+	field, _ := itemErr.Data["title"].(map[string]any)
+	if field["code"] != "validation_required" {
+		t.Fatalf("Data = %v, want the title validation error", itemErr.Data)
+	}
+
+	// Requests without their own entry report the batch error.
+	var batchErr *APIError
+	if !errors.As(ok.Err(), &batchErr) || batchErr.Message != "Batch transaction failed." {
+		t.Fatalf("other item err = %v, want the batch error", ok.Err())
+	}
+}
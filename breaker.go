@@ -0,0 +1,247 @@
+package pbclient
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the server while the circuit
+// breaker for the request's endpoint group is open.
+var ErrCircuitOpen = errors.New("pbclient: circuit open")
+
+// CircuitState is the state of one circuit breaker group.
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// BreakerConfig enables the circuit breaker when set on Config.
+//
+// Requests are grouped by endpoint (see GroupOf) and each group trips
+// independently after FailureThreshold consecutive failures. While open,
+// requests fail fast with ErrCircuitOpen. After OpenTimeout the breaker
+// probes /api/health; on success the group moves to half-open and lets a
+// single request through, closing again once it succeeds.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens a
+	// group. Defaults to 5.
+	FailureThreshold int
+	// Thresholds overrides FailureThreshold per group name.
+	Thresholds map[string]int
+	// OpenTimeout is how long a group stays open before probing. Defaults to 10s.
+	OpenTimeout time.Duration
+	// GroupOf maps a request path to a group name. Defaults to DefaultBreakerGroup.
+	GroupOf func(path string) string
+}
+
+// DefaultBreakerGroup groups requests by the first segments of the API path:
+// "/api/collections/posts/records/x" becomes "collections/posts", and
+// "/api/batch" becomes "batch".
+func DefaultBreakerGroup(p string) string {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(p, "/api/"), "/"), "/")
+	if len(parts) >= 2 && parts[0] == "collections" {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// CircuitStatus reports the state of one breaker group.
+type CircuitStatus struct {
+	Group     string
+	State     CircuitState
+	Failures  int
+	OpenedAt  time.Time
+	LastError error
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	lastErr  error
+	probing  bool
+	trial    bool
+}
+
+type breaker struct {
+	cfg   BreakerConfig
+	probe func() error
+
+	mu     sync.Mutex
+	groups map[string]*circuit
+}
+
+func newBreaker(cfg BreakerConfig, probe func() error) *breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 10 * time.Second
+	}
+	if cfg.GroupOf == nil {
+		cfg.GroupOf = DefaultBreakerGroup
+	}
+	return &breaker{cfg: cfg, probe: probe, groups: map[string]*circuit{}}
+}
+
+func (b *breaker) threshold(group string) int {
+	if n, ok := b.cfg.Thresholds[group]; ok && n > 0 {
+		return n
+	}
+	return b.cfg.FailureThreshold
+}
+
+// allow reports whether a request to path may proceed and returns its group.
+func (b *breaker) allow(path string) (string, error) {
+	group := b.cfg.GroupOf(path)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cb := b.groups[group]
+	if cb == nil {
+		return group, nil
+	}
+	switch cb.state {
+	case CircuitOpen:
+		if time.Since(cb.openedAt) >= b.cfg.OpenTimeout && !cb.probing {
+			cb.probing = true
+			go b.runProbe(group, cb)
+		}
+		return group, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if cb.trial {
+			return group, ErrCircuitOpen
+		}
+		cb.trial = true
+	}
+	return group, nil
+}
+
+// runProbe checks whether the open circuit cb may move to half-open. The
+// result is dropped if cb closed while the probe ran, e.g. because a request
+// started before the circuit opened succeeded.
+func (b *breaker) runProbe(group string, cb *circuit) {
+	err := b.probe()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.groups[group] != cb {
+		return
+	}
+	cb.probing = false
+	if err != nil {
+		cb.openedAt = time.Now()
+		cb.lastErr = err
+		return
+	}
+	cb.state = CircuitHalfOpen
+	cb.trial = false
+}
+
+// record updates the group with the outcome of a request that was allowed.
+func (b *breaker) record(group string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cb := b.groups[group]
+	if err == nil {
+		if cb != nil {
+			delete(b.groups, group)
+		}
+		return
+	}
+	if cb == nil {
+		cb = &circuit{}
+		b.groups[group] = cb
+	}
+	cb.failures++
+	cb.lastErr = err
+	if cb.state == CircuitHalfOpen || cb.failures >= b.threshold(group) {
+		cb.state = CircuitOpen
+		cb.openedAt = time.Now()
+		cb.trial = false
+	}
+}
+
+// release ends a request that produced no verdict, such as one cancelled by
+// the caller, freeing the half-open trial slot without changing the state.
+func (b *breaker) release(group string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if cb := b.groups[group]; cb != nil && cb.state == CircuitHalfOpen {
+		cb.trial = false
+	}
+}
+
+func (b *breaker) status() []CircuitStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]CircuitStatus, 0, len(b.groups))
+	for g, cb := range b.groups {
+		out = append(out, CircuitStatus{
+			Group:     g,
+			State:     cb.state,
+			Failures:  cb.failures,
+			OpenedAt:  cb.openedAt,
+			LastError: cb.lastErr,
+		})
+	}
+	return out
+}
+
+// countsAsFailure reports whether err should count against the breaker.
+// Client errors (4xx) mean the server is answering and do not trip it.
+func countsAsFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status >= 500
+	}
+	return isTransient(err)
+}
+
+// serverAnswered reports whether err, or its absence, reflects a response
+// from the server. Only such outcomes may close a circuit.
+func serverAnswered(err error) bool {
+	var apiErr *APIError
+	return err == nil || errors.As(err, &apiErr)
+}
+
+// Circuits returns the state of every breaker group that has recorded
+// failures. Groups not listed are closed. It returns nil when the breaker is
+// disabled.
+func (c *Client) Circuits() []CircuitStatus {
+	if c.breaker == nil {
+		return nil
+	}
+	return c.breaker.status()
+}
+
+// CircuitOpen reports whether any breaker group is currently open, which is
+// convenient for readiness checks.
+func (c *Client) CircuitOpen() bool {
+	for _, st := range c.Circuits() {
+		if st.State == CircuitOpen {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package pbclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func circuitState(b *breaker, group string) CircuitState {
+	for _, st := range b.status() {
+		if st.Group == group {
+			return st.State
+		}
+	}
+	return CircuitClosed
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	probeErr := errors.New("probe failed")
+	var probeResult error
+	probed := make(chan struct{}, 1)
+	b := newBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Millisecond}, func() error {
+		defer func() { probed <- struct{}{} }()
+		return probeResult
+	})
+	const path = "/api/collections/posts/records"
+	group := DefaultBreakerGroup(path)
+	fail := errors.New("boom")
+
+	for i := 0; i < 2; i++ {
+		if _, err := b.allow(path); err != nil {
+			t.Fatalf("allow %d: %v", i, err)
+		}
+		b.record(group, fail)
+	}
+	if st := circuitState(b, group); st != CircuitOpen {
+		t.Fatalf("state = %s, want open", st)
+	}
+
+	// A failing probe keeps the circuit open.
+	probeResult = probeErr
+	time.Sleep(5 * time.Millisecond)
+	if _, err := b.allow(path); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow while open = %v, want ErrCircuitOpen", err)
+	}
+	<-probed
+	waitFor(t, func() bool { return !probing(b, group) })
+	if st := circuitState(b, group); st != CircuitOpen {
+		t.Fatalf("state after failed probe = %s, want open", st)
+	}
+
+	// A passing probe moves to half-open with a single trial slot.
+	probeResult = nil
+	time.Sleep(5 * time.Millisecond)
+	b.allow(path)
+	<-probed
+	waitFor(t, func() bool { return circuitState(b, group) == CircuitHalfOpen })
+
+	if _, err := b.allow(path); err != nil {
+		t.Fatalf("trial allow: %v", err)
+	}
+	if _, err := b.allow(path); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second allow during trial = %v, want ErrCircuitOpen", err)
+	}
+
+	// A trial without a verdict frees the slot but stays half-open.
+	b.release(group)
+	if st := circuitState(b, group); st != CircuitHalfOpen {
+		t.Fatalf("state after release = %s, want half-open", st)
+	}
+	if _, err := b.allow(path); err != nil {
+		t.Fatalf("allow after release: %v", err)
+	}
+	b.record(group, nil)
+	if st := circuitState(b, group); st != CircuitClosed {
+		t.Fatalf("state after successful trial = %s, want closed", st)
+	}
+}
+
+// TestBreakerProbeAfterClose covers a request that was in flight when the
+// circuit opened and succeeds while the health probe is running.
+func TestBreakerProbeAfterClose(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	done := make(chan struct{})
+	b := newBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Millisecond}, func() error {
+		defer close(done)
+		close(started)
+		<-unblock
+		return nil
+	})
+	const path = "/api/collections/posts/records"
+	group := DefaultBreakerGroup(path)
+
+	b.allow(path) // the slow request
+	b.allow(path)
+	b.record(group, errors.New("boom"))
+
+	time.Sleep(5 * time.Millisecond)
+	if _, err := b.allow(path); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow while open = %v, want ErrCircuitOpen", err)
+	}
+	<-started
+	b.record(group, nil) // the slow request succeeds
+	close(unblock)
+	<-done
+
+	// The stale probe must neither panic nor resurrect the closed circuit.
+	time.Sleep(10 * time.Millisecond)
+	if st := circuitState(b, group); st != CircuitClosed {
+		t.Fatalf("state = %s, want closed", st)
+	}
+	if _, err := b.allow(path); err != nil {
+		t.Fatalf("allow after close: %v", err)
+	}
+}
+
+func probing(b *breaker, group string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cb := b.groups[group]
+	return cb != nil && cb.probing
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 1s")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,300 @@
+package pbclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheConfig enables an in-memory cache for record reads when set on Config.
+//
+// Only GET requests under /api/collections/{name}/records are cached, keyed by
+// path, query and the current auth token, so different identities never share
+// entries. Identical concurrent misses are collapsed into one request. When the
+// server returns an ETag, expired entries are revalidated with If-None-Match.
+//
+// Entries for a collection are dropped when this client writes to it, directly
+// or through a Batch, when a Realtime connection on this client receives an
+// event for it, or via Client.InvalidateCache.
+type CacheConfig struct {
+	// DefaultTTL applies to collections missing from TTLs. Zero disables
+	// caching for those collections.
+	DefaultTTL time.Duration
+	// TTLs sets the cache lifetime per collection name.
+	TTLs map[string]time.Duration
+	// MaxEntries bounds the number of cached responses. Defaults to 1000.
+	MaxEntries int
+}
+
+type cacheEntry struct {
+	collection string
+	body       []byte
+	etag       string
+	expires    time.Time
+	stored     time.Time
+}
+
+type responseCache struct {
+	cfg CacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	gens    map[string]uint64
+
+	flight flightGroup
+}
+
+func newResponseCache(cfg CacheConfig) *responseCache {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = 1000
+	}
+	return &responseCache{cfg: cfg, entries: map[string]*cacheEntry{}, gens: map[string]uint64{}}
+}
+
+func (rc *responseCache) ttl(collection string) time.Duration {
+	if d, ok := rc.cfg.TTLs[collection]; ok {
+		return d
+	}
+	return rc.cfg.DefaultTTL
+}
+
+// doJSON serves cacheable requests and invalidates on record writes. It
+// reports handled=false when the request should go through the normal path.
+func (rc *responseCache) doJSON(c *Client, r *request, out any) (handled bool, err error) {
+	collection, ok := recordsCollection(r.endpoint)
+	if !ok {
+		return false, nil
+	}
+	if r.method != http.MethodGet {
+		b, err := c.fetch(r)
+		rc.invalidateWrite(r.method, r.endpoint)
+		if err != nil || out == nil {
+			return true, err
+		}
+		return true, json.Unmarshal(b, out)
+	}
+	ttl := rc.ttl(collection)
+	if ttl <= 0 {
+		return false, nil
+	}
+
+	key := r.endpoint + "?" + r.query + "#" + tokenKey(c.Token())
+
+	rc.mu.Lock()
+	e := rc.entries[key]
+	rc.mu.Unlock()
+	if e != nil && time.Now().Before(e.expires) {
+		return true, decodeCached(e.body, out)
+	}
+
+	b, err := rc.flight.do(key, func() ([]byte, error) {
+		return rc.load(c, r, key, collection, ttl, e)
+	})
+	if err != nil {
+		return true, err
+	}
+	return true, decodeCached(b, out)
+}
+
+func (rc *responseCache) load(c *Client, r *request, key, collection string, ttl time.Duration, stale *cacheEntry) ([]byte, error) {
+	rc.mu.Lock()
+	gen := rc.gens[collection]
+	rc.mu.Unlock()
+
+	req := *r
+	if stale != nil && stale.etag != "" {
+		req.header = http.Header{"If-None-Match": {stale.etag}}
+	}
+	resp, err := c.do(&req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body []byte
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode == http.StatusNotModified {
+		body = stale.body
+		if etag == "" {
+			etag = stale.etag
+		}
+	} else if body, err = c.readBody(resp.Body); err != nil {
+		return nil, err
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.gens[collection] == gen {
+		rc.evictLocked()
+		now := time.Now()
+		rc.entries[key] = &cacheEntry{collection: collection, body: body, etag: etag, expires: now.Add(ttl), stored: now}
+	}
+	return body, nil
+}
+
+// evictLocked makes room for one entry, dropping expired entries first and the
+// oldest one otherwise. Callers must hold rc.mu.
+func (rc *responseCache) evictLocked() {
+	if len(rc.entries) < rc.cfg.MaxEntries {
+		return
+	}
+	now := time.Now()
+	var oldestKey string
+	var oldest time.Time
+	for k, e := range rc.entries {
+		if now.After(e.expires) && e.etag == "" {
+			delete(rc.entries, k)
+			continue
+		}
+		if oldestKey == "" || e.stored.Before(oldest) {
+			oldestKey, oldest = k, e.stored
+		}
+	}
+	if len(rc.entries) >= rc.cfg.MaxEntries && oldestKey != "" {
+		delete(rc.entries, oldestKey)
+	}
+}
+
+// invalidate drops cached entries for the given collections, or all entries
+// when none are given.
+func (rc *responseCache) invalidate(collections ...string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if len(collections) == 0 {
+		for k, e := range rc.entries {
+			rc.gens[e.collection]++
+			delete(rc.entries, k)
+		}
+		return
+	}
+	for _, name := range collections {
+		rc.gens[name]++
+		for k, e := range rc.entries {
+			if e.collection == name {
+				delete(rc.entries, k)
+			}
+		}
+	}
+}
+
+// invalidateWrite drops entries for the collection a request wrote to. It is
+// called once the write has returned, whatever its outcome: reads that raced
+// the write then either see the new state or are discarded by the generation
+// check in load, instead of caching the old state for a full TTL.
+func (rc *responseCache) invalidateWrite(method, endpoint string) {
+	if method == http.MethodGet {
+		return
+	}
+	if name, ok := recordsCollection(endpoint); ok {
+		rc.invalidate(name)
+	}
+}
+
+// invalidateBatch drops entries for every collection an /api/batch call
+// wrote to.
+func (rc *responseCache) invalidateBatch(reqs []BatchRequest) {
+	for _, r := range reqs {
+		p, _, _ := strings.Cut(r.URL, "?")
+		if name, ok := recordsCollection(p); ok {
+			rc.invalidate(name)
+		}
+	}
+}
+
+// invalidateEvent drops entries for the collection a realtime event refers to.
+func (rc *responseCache) invalidateEvent(ev RealtimeEvent) {
+	if ev.Event == "" || strings.HasPrefix(ev.Event, "PB_") {
+		return
+	}
+	var payload struct {
+		Record struct {
+			CollectionName string `json:"collectionName"`
+		} `json:"record"`
+	}
+	_ = json.Unmarshal(ev.Data, &payload)
+	name := payload.Record.CollectionName
+	if name == "" {
+		name, _, _ = strings.Cut(ev.Event, "/")
+		name, _, _ = strings.Cut(name, "?")
+	}
+	if name != "" {
+		rc.invalidate(name)
+	}
+}
+
+// InvalidateCache drops cached responses for the given collections, or the
+// whole cache when called without arguments. It is a no-op when caching is
+// disabled.
+func (c *Client) InvalidateCache(collections ...string) {
+	if c.cache != nil {
+		c.cache.invalidate(collections...)
+	}
+}
+
+// recordsCollection extracts the collection name from a records endpoint.
+func recordsCollection(endpoint string) (string, bool) {
+	rest, ok := strings.CutPrefix(endpoint, "/api/collections/")
+	if !ok {
+		return "", false
+	}
+	name, rest, _ := strings.Cut(rest, "/")
+	if name == "" || !(rest == "records" || strings.HasPrefix(rest, "records/")) {
+		return "", false
+	}
+	return name, true
+}
+
+func tokenKey(token string) string {
+	if token == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+func decodeCached(b []byte, out any) error {
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(b, out)
+}
+
+// flightGroup collapses concurrent calls with the same key into one.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	wg  sync.WaitGroup
+	val []byte
+	err error
+}
+
+func (g *flightGroup) do(key string, fn func() ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*flightCall{}
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.val, call.err
+	}
+	call := &flightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.val, call.err = fn()
+	call.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	return call.val, call.err
+}
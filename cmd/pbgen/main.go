@@ -0,0 +1,251 @@
+// Command pbgen generates Go types from PocketBase collection schemas.
+//
+// Usage:
+//
+//	pbgen -url http://127.0.0.1:8090 -email admin@example.com -password secret -pkg models -out models/pb.go
+//	pbgen -schema pb_schema.json -pkg models -out models/pb.go
+//
+// Credentials may also come from PB_SUPER_EMAIL and PB_SUPER_PASSWORD.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	pbclient "github.com/chrisbrocklesby/pbclient"
+)
+
+func main() {
+	baseURL := flag.String("url", "http://127.0.0.1:8090", "PocketBase base URL")
+	email := flag.String("email", os.Getenv("PB_SUPER_EMAIL"), "superuser email")
+	password := flag.String("password", os.Getenv("PB_SUPER_PASSWORD"), "superuser password")
+	schema := flag.String("schema", "", "read collections from an exported schema JSON file instead of the server")
+	pkg := flag.String("pkg", "models", "package name of the generated file")
+	out := flag.String("out", "", "output file (default stdout)")
+	system := flag.Bool("system", false, "include system collections such as _superusers")
+	flag.Parse()
+
+	var cols []pbclient.CollectionModel
+	var err error
+	if *schema != "" {
+		cols, err = pbclient.LoadSnapshotFile(*schema)
+	} else {
+		var c *pbclient.Client
+		c, err = pbclient.NewClient(pbclient.Config{BaseURL: *baseURL, SuperEmail: *email, SuperPassword: *password})
+		if err == nil {
+			cols, err = c.Collections().FullList()
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(*pkg, cols, *system)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		_, _ = os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(pkg string, cols []pbclient.CollectionModel, includeSystem bool) ([]byte, error) {
+	sort.Slice(cols, func(i, j int) bool { return cols[i].Name < cols[j].Name })
+
+	var body bytes.Buffer
+	imports := map[string]bool{`pbclient "github.com/chrisbrocklesby/pbclient"`: true}
+
+	body.WriteString("// Collection names.\nconst (\n")
+	for _, col := range cols {
+		if skipCollection(col, includeSystem) {
+			continue
+		}
+		fmt.Fprintf(&body, "\tCollection%s = %q\n", goName(col.Name), col.Name)
+	}
+	body.WriteString(")\n\n")
+
+	for _, col := range cols {
+		if skipCollection(col, includeSystem) {
+			continue
+		}
+		typ := singular(goName(col.Name))
+
+		fmt.Fprintf(&body, "// %s field names.\nconst (\n", typ)
+		for _, f := range col.Fields {
+			if skipField(f) {
+				continue
+			}
+			fmt.Fprintf(&body, "\t%sField%s = %q\n", typ, goName(f.Name), f.Name)
+		}
+		body.WriteString(")\n\n")
+
+		fmt.Fprintf(&body, "// %s is a record of the %q collection.\ntype %s struct {\n", typ, col.Name, typ)
+		hasID := false
+		for _, f := range col.Fields {
+			if skipField(f) {
+				continue
+			}
+			goType, imp := fieldType(f)
+			if imp != "" {
+				imports[imp] = true
+			}
+			tag := f.Name
+			if f.Name == "id" || f.Type == pbclient.FieldAutodate || f.Type == pbclient.FieldPassword {
+				tag += ",omitempty"
+			}
+			if f.Name == "id" {
+				hasID = true
+			}
+			fmt.Fprintf(&body, "\t%s %s `json:%q`\n", goName(f.Name), goType, tag)
+		}
+		if !hasID {
+			body.WriteString("\tID string `json:\"id,omitempty\"`\n")
+		}
+		body.WriteString("\tCollectionID string `json:\"collectionId,omitempty\"`\n")
+		body.WriteString("\tCollectionName string `json:\"collectionName,omitempty\"`\n")
+		body.WriteString("}\n\n")
+
+		fmt.Fprintf(&body, "// %sCollection returns a typed handle for %q.\n", goName(col.Name), col.Name)
+		fmt.Fprintf(&body, "func %sCollection(client ...*pbclient.Client) *pbclient.Coll[%s] {\n", goName(col.Name), typ)
+		fmt.Fprintf(&body, "\treturn pbclient.Collection[%s](Collection%s, client...)\n}\n\n", typ, goName(col.Name))
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by pbgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\nimport (\n", pkg)
+	var std, ext []string
+	for _, imp := range sortedImports(imports) {
+		if strings.Contains(imp, ".") {
+			ext = append(ext, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+	for _, imp := range std {
+		fmt.Fprintf(&src, "\t%s\n", imp)
+	}
+	if len(std) > 0 {
+		src.WriteString("\n")
+	}
+	for _, imp := range ext {
+		fmt.Fprintf(&src, "\t%s\n", imp)
+	}
+	src.WriteString(")\n\n")
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+func skipCollection(col pbclient.CollectionModel, includeSystem bool) bool {
+	return !includeSystem && (col.System || strings.HasPrefix(col.Name, "_"))
+}
+
+func skipField(f pbclient.Field) bool {
+	return f.Name == "tokenKey" && f.Hidden && f.System
+}
+
+// fieldType maps a PocketBase field to a Go type and an optional import.
+func fieldType(f pbclient.Field) (string, string) {
+	multi := f.MaxSelect > 1
+	switch f.Type {
+	case pbclient.FieldNumber:
+		if onlyInt, _ := f.Options["onlyInt"].(bool); onlyInt {
+			return "int64", ""
+		}
+		return "float64", ""
+	case pbclient.FieldBool:
+		return "bool", ""
+	case pbclient.FieldSelect, pbclient.FieldRelation, pbclient.FieldFile:
+		if multi {
+			return "[]string", ""
+		}
+		return "string", ""
+	case pbclient.FieldJSON:
+		return "json.RawMessage", `"encoding/json"`
+	case pbclient.FieldGeoPoint:
+		return "pbclient.GeoPoint", ""
+	default:
+		// text, editor, email, url, password, date, autodate and unknown types.
+		return "string", ""
+	}
+}
+
+var initialisms = map[string]string{"id": "ID", "url": "URL", "api": "API", "http": "HTTP", "json": "JSON", "ip": "IP", "uri": "URI"}
+
+// goName converts a PocketBase name such as "_pb_users_auth_" or "createdAt"
+// to an exported Go identifier.
+func goName(name string) string {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = cur[:0]
+		}
+	}
+	for i, r := range name {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0:
+			flush()
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+
+	var b strings.Builder
+	for _, w := range words {
+		if up, ok := initialisms[strings.ToLower(w)]; ok {
+			b.WriteString(up)
+			continue
+		}
+		rs := []rune(w)
+		rs[0] = unicode.ToUpper(rs[0])
+		b.WriteString(string(rs))
+	}
+	s := b.String()
+	if s == "" || unicode.IsDigit([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
+
+// singular turns a plural collection type name into a record type name.
+func singular(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies") && len(s) > 3:
+		return s[:len(s)-3] + "y"
+	case strings.HasSuffix(s, "ss"), strings.HasSuffix(s, "us"), strings.HasSuffix(s, "is"):
+		return s
+	case strings.HasSuffix(s, "s") && len(s) > 1:
+		return s[:len(s)-1]
+	}
+	return s
+}
+
+func sortedImports(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -1,9 +1,12 @@
 package pbclient
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
 type ListResult[T any] struct {
@@ -16,8 +19,9 @@ type ListResult[T any] struct {
 
 // Coll provides typed CRUD helpers for a PocketBase collection.
 type Coll[T any] struct {
-	c    *Client
-	name string
+	c     *Client
+	name  string
+	query string
 }
 
 // Collection returns a typed collection. If a client is provided, it is used; otherwise the package default client is used.
@@ -29,36 +33,58 @@ func Collection[T any](name string, client ...*Client) *Coll[T] {
 	return &Coll[T]{c: c, name: name}
 }
 
+// Name returns the collection name.
+func (col *Coll[T]) Name() string { return col.name }
+
+// WithQuery returns a copy of the collection whose requests always include
+// the raw query q, e.g. "expand=author". Per-call params are appended after it.
+func (col *Coll[T]) WithQuery(q string) *Coll[T] {
+	cp := *col
+	cp.query = mergeQuery(col.query, joinQuery(q))
+	return &cp
+}
+
+// params merges the default query with the optional per-call params.
+func (col *Coll[T]) params(p []string) string {
+	return mergeQuery(col.query, optParam(p))
+}
+
+// InBatch returns a typed batch view of the collection that enqueues into b,
+// reusing the collection name, record type and default query.
+func (col *Coll[T]) InBatch(b *Batch) *TypedBatchCollection[T] {
+	return &TypedBatchCollection[T]{b: b, name: col.name, query: col.query}
+}
+
 // Create inserts a record in the collection.
 func (col *Coll[T]) Create(record any, params ...string) (T, error) {
 	var out T
-	err := col.c.doJSON(http.MethodPost, "/api/collections/"+url.PathEscape(col.name)+"/records", optParam(params), record, &out)
+	err := col.c.doJSON(http.MethodPost, "/api/collections/"+url.PathEscape(col.name)+"/records", col.params(params), record, &out)
 	return out, err
 }
 
 // Get retrieves a record by ID.
 func (col *Coll[T]) Get(id string, params ...string) (T, error) {
 	var out T
-	err := col.c.doJSON(http.MethodGet, "/api/collections/"+url.PathEscape(col.name)+"/records/"+url.PathEscape(id), optParam(params), nil, &out)
+	err := col.c.doJSON(http.MethodGet, "/api/collections/"+url.PathEscape(col.name)+"/records/"+url.PathEscape(id), col.params(params), nil, &out)
 	return out, err
 }
 
 // Update patches a record by ID.
 func (col *Coll[T]) Update(id string, patch any, params ...string) (T, error) {
 	var out T
-	err := col.c.doJSON(http.MethodPatch, "/api/collections/"+url.PathEscape(col.name)+"/records/"+url.PathEscape(id), optParam(params), patch, &out)
+	err := col.c.doJSON(http.MethodPatch, "/api/collections/"+url.PathEscape(col.name)+"/records/"+url.PathEscape(id), col.params(params), patch, &out)
 	return out, err
 }
 
 // Delete removes a record by ID.
 func (col *Coll[T]) Delete(id string, params ...string) error {
-	return col.c.doJSON(http.MethodDelete, "/api/collections/"+url.PathEscape(col.name)+"/records/"+url.PathEscape(id), optParam(params), nil, nil)
+	return col.c.doJSON(http.MethodDelete, "/api/collections/"+url.PathEscape(col.name)+"/records/"+url.PathEscape(id), col.params(params), nil, nil)
 }
 
 // List returns a paginated list response for the collection.
 func (col *Coll[T]) List(params ...string) (ListResult[T], error) {
 	var out ListResult[T]
-	err := col.c.doJSON(http.MethodGet, "/api/collections/"+url.PathEscape(col.name)+"/records", optParam(params), nil, &out)
+	err := col.c.doJSON(http.MethodGet, "/api/collections/"+url.PathEscape(col.name)+"/records", col.params(params), nil, &out)
 	return out, err
 }
 
@@ -81,3 +107,106 @@ func (col *Coll[T]) First(params ...string) (T, error) {
 	}
 	return res.Items[0], nil
 }
+
+// Stream requests a single list page and decodes its items one at a time,
+// calling fn for each. Unlike List, the response is not buffered and is not
+// subject to Config.MaxResponseSize. The returned ListResult carries the page
+// metadata with a nil Items slice. Returning an error from fn stops decoding.
+func (col *Coll[T]) Stream(fn func(T) error, params ...string) (ListResult[T], error) {
+	var meta ListResult[T]
+	err := col.c.doStream(http.MethodGet, "/api/collections/"+url.PathEscape(col.name)+"/records", col.params(params), nil, func(r io.Reader) error {
+		_, err := decodeListStream(r, &meta, fn)
+		return err
+	})
+	return meta, err
+}
+
+// StreamAll walks every page matching the query params, streaming each item to
+// fn. Any page param in params is ignored; perPage and skipTotal are honoured.
+func (col *Coll[T]) StreamAll(fn func(T) error, params ...string) error {
+	q := col.params(params)
+	for page := 1; ; page++ {
+		var meta ListResult[T]
+		var n int
+		err := col.c.doStream(http.MethodGet, "/api/collections/"+url.PathEscape(col.name)+"/records", withParam(q, "page", strconv.Itoa(page)), nil, func(r io.Reader) error {
+			var err error
+			n, err = decodeListStream(r, &meta, fn)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 || n < meta.PerPage || (meta.TotalPages > 0 && page >= meta.TotalPages) {
+			return nil
+		}
+	}
+}
+
+// decodeListStream decodes a PocketBase list response token by token, filling
+// meta with the pagination fields and passing each item to fn. It returns the
+// number of items decoded.
+func decodeListStream[T any](r io.Reader, meta *ListResult[T], fn func(T) error) (int, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return 0, err
+	}
+	n := 0
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return n, err
+		}
+		key, _ := tok.(string)
+		switch key {
+		case "page":
+			err = dec.Decode(&meta.Page)
+		case "perPage":
+			err = dec.Decode(&meta.PerPage)
+		case "totalItems":
+			err = dec.Decode(&meta.TotalItems)
+		case "totalPages":
+			err = dec.Decode(&meta.TotalPages)
+		case "items":
+			tok, err = dec.Token()
+			if err != nil {
+				return n, err
+			}
+			if tok == nil {
+				continue
+			}
+			if d, ok := tok.(json.Delim); !ok || d != '[' {
+				return n, fmt.Errorf("pbclient: unexpected token %v for items", tok)
+			}
+			for dec.More() {
+				var item T
+				if err := dec.Decode(&item); err != nil {
+					return n, err
+				}
+				n++
+				if err := fn(item); err != nil {
+					return n, err
+				}
+			}
+			_, err = dec.Token()
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+	_, err := dec.Token()
+	return n, err
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("pbclient: expected %q, got %v", want, tok)
+	}
+	return nil
+}
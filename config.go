@@ -11,9 +11,32 @@ import (
 
 type Config struct {
 	BaseURL string
+
+	// BaseURLs lists several PocketBase endpoints for failover. The first entry
+	// is the primary and receives all writes; the rest are read replicas. When
+	// set, BaseURL is ignored. Reads may observe replication lag. Health
+	// checks (Health, HealthDetails, WaitReady), backups, logs and crons
+	// always use the primary.
+	BaseURLs []string
+	// FailoverCooldown is how long an unreachable endpoint, or one answering
+	// with 5xx, is skipped before it is re-probed via /api/health. Defaults
+	// to 5s.
+	FailoverCooldown time.Duration
+
 	Timeout time.Duration
 	HTTP    *http.Client
 
+	// Breaker enables a circuit breaker that fails fast with ErrCircuitOpen
+	// while PocketBase is unreachable. Nil disables it.
+	Breaker *BreakerConfig
+
+	// Cache enables in-memory caching of record reads. Nil disables it.
+	Cache *CacheConfig
+
+	// MaxResponseSize caps buffered response bodies in bytes. Zero uses the
+	// default of 32 MiB; a negative value disables the limit.
+	MaxResponseSize int64
+
 	UserEmail      string
 	UserPassword   string
 	UserCollection string
@@ -29,16 +52,27 @@ type Config struct {
 
 // Client is a PocketBase API client.
 type Client struct {
-	baseURL string
-	http    *http.Client
-	ctx     context.Context
+	baseURL   string
+	endpoints *endpointSelector
+	breaker   *breaker
+	cache     *responseCache
+	http      *http.Client
+	ctx       context.Context
+
+	mu        sync.RWMutex
+	token     string
+	serverAPI ServerAPI
 
-	mu    sync.RWMutex
-	token string
+	maxResponseSize int64
+
+	rtMu sync.Mutex
+	rt   *Realtime
 
 	logger *log.Logger
 }
 
+const defaultMaxResponseSize = 32 << 20
+
 func normalizeBaseURL(s string) string {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -50,7 +84,17 @@ func normalizeBaseURL(s string) string {
 // NewClient creates a client from config and optionally performs initial login
 // when auth credentials are provided.
 func NewClient(cfg Config) (*Client, error) {
-	base := normalizeBaseURL(cfg.BaseURL)
+	bases := []string{normalizeBaseURL(cfg.BaseURL)}
+	if len(cfg.BaseURLs) > 0 {
+		bases = bases[:0]
+		for _, u := range cfg.BaseURLs {
+			bases = append(bases, normalizeBaseURL(u))
+		}
+	}
+	cooldown := cfg.FailoverCooldown
+	if cooldown <= 0 {
+		cooldown = 5 * time.Second
+	}
 	timeout := cfg.Timeout
 	if timeout <= 0 {
 		timeout = 15 * time.Second
@@ -61,11 +105,25 @@ func NewClient(cfg Config) (*Client, error) {
 		hc = &http.Client{Timeout: timeout}
 	}
 
+	maxResp := cfg.MaxResponseSize
+	if maxResp == 0 {
+		maxResp = defaultMaxResponseSize
+	}
+
 	c := &Client{
-		baseURL: base,
+		baseURL: bases[0],
 		http:    hc,
 		ctx:     context.Background(),
 		logger:  cfg.Logger,
+
+		maxResponseSize: maxResp,
+	}
+	c.endpoints = newEndpointSelector(bases, cooldown, c.healthAt)
+	if cfg.Cache != nil {
+		c.cache = newResponseCache(*cfg.Cache)
+	}
+	if cfg.Breaker != nil {
+		c.breaker = newBreaker(*cfg.Breaker, func() error { return c.healthAt(c.baseURL) })
 	}
 
 	if err := c.LoginFromConfig(cfg); err != nil {
@@ -0,0 +1,34 @@
+package pbclient
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// CronJob is a registered PocketBase cron job (v0.24+).
+type CronJob struct {
+	ID         string `json:"id"`
+	Expression string `json:"expression"`
+}
+
+// CronsService lists and triggers cron jobs via /api/crons. All operations
+// require superuser auth.
+type CronsService struct {
+	c *Client
+}
+
+// Crons returns the crons service.
+func (c *Client) Crons() *CronsService { return &CronsService{c: c} }
+
+// List returns the registered cron jobs.
+func (s *CronsService) List() ([]CronJob, error) {
+	var out []CronJob
+	err := s.c.doJSON(http.MethodGet, "/api/crons", "", nil, &out)
+	return out, err
+}
+
+// Run triggers the cron job with the given ID. PocketBase starts the job in
+// the background and returns immediately.
+func (s *CronsService) Run(id string) error {
+	return s.c.doJSON(http.MethodPost, "/api/crons/"+url.PathEscape(id), "", nil, nil)
+}
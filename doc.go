@@ -15,6 +15,7 @@
 //   - Batch helper for /api/batch with shared auth context
 //   - Realtime SSE client with reconnect + resubscribe and a buffered Events channel
 //   - Auth helpers for users/admins/superusers with token storage
+//   - Collections schema management for superusers
 //   - GET retries on transient failures; Health and WaitReady utilities
 //
 // Keep credentials in Config; prefer explicit clients for services, with the default client for quick scripts.
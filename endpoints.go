@@ -0,0 +1,196 @@
+package pbclient
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointStatus reports the health of one configured base URL.
+type EndpointStatus struct {
+	URL       string
+	Primary   bool
+	Healthy   bool
+	LastError error
+	DownSince time.Time
+}
+
+type endpoint struct {
+	url     string
+	primary bool
+
+	healthy   bool
+	probing   bool
+	lastErr   error
+	downSince time.Time
+	retryAt   time.Time
+}
+
+// endpointSelector routes writes to the primary and spreads reads across
+// healthy replicas. Endpoints marked down are re-probed via /api/health after
+// a cooldown and brought back once the probe succeeds.
+type endpointSelector struct {
+	mu       sync.Mutex
+	all      []*endpoint
+	replicas []*endpoint
+	next     int
+	cooldown time.Duration
+	probe    func(base string) error
+}
+
+func newEndpointSelector(urls []string, cooldown time.Duration, probe func(string) error) *endpointSelector {
+	s := &endpointSelector{cooldown: cooldown, probe: probe}
+	for i, u := range urls {
+		ep := &endpoint{url: u, primary: i == 0, healthy: true}
+		s.all = append(s.all, ep)
+		if i > 0 {
+			s.replicas = append(s.replicas, ep)
+		}
+	}
+	return s
+}
+
+// pick returns the base URL to use for a request. Writes and primary-only
+// endpoints always go to the primary. Reads prefer healthy replicas in
+// round-robin order, then the primary, and only use a down endpoint when
+// nothing else is available.
+func (s *endpointSelector) pick(method, endpoint string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scheduleProbes()
+
+	primary := s.all[0]
+	if !isReadMethod(method) || primaryOnly(endpoint) || len(s.replicas) == 0 {
+		return primary.url
+	}
+	for i := 0; i < len(s.replicas); i++ {
+		ep := s.replicas[(s.next+i)%len(s.replicas)]
+		if ep.healthy {
+			s.next = (s.next + i + 1) % len(s.replicas)
+			return ep.url
+		}
+	}
+	return primary.url
+}
+
+// scheduleProbes starts a background health probe for every down endpoint
+// whose cooldown has elapsed. Callers must hold s.mu.
+func (s *endpointSelector) scheduleProbes() {
+	now := time.Now()
+	for _, ep := range s.all {
+		if ep.healthy || ep.probing || now.Before(ep.retryAt) {
+			continue
+		}
+		ep.probing = true
+		go s.runProbe(ep)
+	}
+}
+
+func (s *endpointSelector) runProbe(ep *endpoint) {
+	err := s.probe(ep.url)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ep.probing = false
+	s.setLocked(ep, err)
+}
+
+func (s *endpointSelector) markDown(base string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ep := s.find(base); ep != nil {
+		s.setLocked(ep, err)
+	}
+}
+
+func (s *endpointSelector) markUp(base string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ep := s.find(base); ep != nil && !ep.healthy {
+		s.setLocked(ep, nil)
+	}
+}
+
+func (s *endpointSelector) setLocked(ep *endpoint, err error) {
+	if err == nil {
+		ep.healthy = true
+		ep.lastErr = nil
+		ep.downSince = time.Time{}
+		return
+	}
+	if ep.healthy {
+		ep.downSince = time.Now()
+	}
+	ep.healthy = false
+	ep.lastErr = err
+	ep.retryAt = time.Now().Add(s.cooldown)
+}
+
+func (s *endpointSelector) find(base string) *endpoint {
+	for _, ep := range s.all {
+		if ep.url == base {
+			return ep
+		}
+	}
+	return nil
+}
+
+// checkAll probes every endpoint synchronously and updates its state.
+func (s *endpointSelector) checkAll() {
+	var wg sync.WaitGroup
+	for _, ep := range s.all {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			err := s.probe(ep.url)
+			s.mu.Lock()
+			s.setLocked(ep, err)
+			s.mu.Unlock()
+		}(ep)
+	}
+	wg.Wait()
+}
+
+func (s *endpointSelector) status() []EndpointStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]EndpointStatus, 0, len(s.all))
+	for _, ep := range s.all {
+		out = append(out, EndpointStatus{
+			URL:       ep.url,
+			Primary:   ep.primary,
+			Healthy:   ep.healthy,
+			LastError: ep.lastErr,
+			DownSince: ep.downSince,
+		})
+	}
+	return out
+}
+
+// primaryOnly reports whether endpoint refers to per-instance state, such as
+// backup files, request logs or registered cron jobs, that replicas do not
+// share. Health checks go to the primary too, so Health and WaitReady describe
+// the instance that takes writes; replicas are probed directly by the selector.
+func primaryOnly(endpoint string) bool {
+	return endpoint == "/api/health" ||
+		strings.HasPrefix(endpoint, "/api/backups") ||
+		strings.HasPrefix(endpoint, "/api/logs") ||
+		strings.HasPrefix(endpoint, "/api/crons")
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// Endpoints returns the health of every configured base URL. The first entry
+// is the primary.
+func (c *Client) Endpoints() []EndpointStatus {
+	return c.endpoints.status()
+}
+
+// CheckEndpoints probes every configured base URL via /api/health and updates
+// the routing state immediately instead of waiting for the next request.
+func (c *Client) CheckEndpoints() {
+	c.endpoints.checkAll()
+}
@@ -2,11 +2,16 @@ package pbclient
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
+// ErrResponseTooLarge is returned when a buffered response body exceeds
+// Config.MaxResponseSize. Use the streaming helpers for large listings.
+var ErrResponseTooLarge = errors.New("pbclient: response too large")
+
 // APIError captures PocketBase error payloads with HTTP status and message.
 type APIError struct {
 	Status  int
@@ -2,14 +2,108 @@ package pbclient
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path"
 	"time"
 )
 
+// HealthResult is the /api/health response. The data fields are only
+// populated for superuser requests.
+type HealthResult struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		CanBackup           bool   `json:"canBackup"`
+		RealIP              string `json:"realIP"`
+		PossibleProxyHeader string `json:"possibleProxyHeader"`
+	} `json:"data"`
+}
+
 // Health checks /api/health.
 func (c *Client) Health() error {
-	var out map[string]any
-	return c.doJSON(http.MethodGet, "/api/health", "", nil, &out)
+	_, err := c.HealthDetails()
+	return err
+}
+
+// HealthDetails checks /api/health and returns the decoded response.
+func (c *Client) HealthDetails() (HealthResult, error) {
+	var out HealthResult
+	err := c.doJSON(http.MethodGet, "/api/health", "", nil, &out)
+	return out, err
+}
+
+// ServerAPI identifies which admin API generation a server speaks.
+type ServerAPI int
+
+const (
+	ServerAPIUnknown ServerAPI = iota
+	// ServerAPIAdmins is PocketBase v0.22 and earlier, with /api/admins.
+	ServerAPIAdmins
+	// ServerAPISuperusers is PocketBase v0.23+, with the _superusers collection.
+	ServerAPISuperusers
+)
+
+func (v ServerAPI) String() string {
+	switch v {
+	case ServerAPIAdmins:
+		return "admins (<= v0.22)"
+	case ServerAPISuperusers:
+		return "superusers (>= v0.23)"
+	}
+	return "unknown"
+}
+
+// ServerAPI detects whether the server uses the v0.22 admins API or the v0.23+
+// _superusers collection by probing the public auth-methods endpoint of
+// _superusers. The result is cached on the client after the first success.
+func (c *Client) ServerAPI() (ServerAPI, error) {
+	c.mu.RLock()
+	v := c.serverAPI
+	c.mu.RUnlock()
+	if v != ServerAPIUnknown {
+		return v, nil
+	}
+
+	err := c.doJSON(http.MethodGet, "/api/collections/_superusers/auth-methods", "", nil, nil)
+	switch {
+	case err == nil:
+		v = ServerAPISuperusers
+	case isNotFound(err):
+		v = ServerAPIAdmins
+	default:
+		return ServerAPIUnknown, err
+	}
+
+	c.mu.Lock()
+	c.serverAPI = v
+	c.mu.Unlock()
+	return v, nil
+}
+
+// healthAt probes /api/health on a specific base URL without retries or
+// endpoint selection. It backs failover decisions.
+func (c *Client) healthAt(base string) error {
+	u, err := url.Parse(base)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, "/api/health")
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return parseAPIError(resp.StatusCode, b)
+	}
+	return nil
 }
 
 // WaitReady polls /api/health until it succeeds or the timeout elapses.
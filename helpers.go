@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net"
+	"net/url"
 	"strings"
 )
 
@@ -24,6 +25,31 @@ func optParam(p []string) string {
 	return joinQuery(p[0])
 }
 
+// mergeQuery joins two raw query strings.
+func mergeQuery(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + "&" + b
+}
+
+// withParam returns the raw query q with any existing key params replaced by
+// key=value. Other params are left untouched and not re-encoded.
+func withParam(q, key, value string) string {
+	var parts []string
+	for _, p := range strings.Split(q, "&") {
+		if p == "" || p == key || strings.HasPrefix(p, key+"=") {
+			continue
+		}
+		parts = append(parts, p)
+	}
+	parts = append(parts, key+"="+url.QueryEscape(value))
+	return strings.Join(parts, "&")
+}
+
 func isTransient(err error) bool {
 	if err == nil {
 		return false
@@ -2,6 +2,7 @@ package pbclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,50 +12,147 @@ import (
 	"time"
 )
 
+// request describes one API call. A stream is sent once without retries; a
+// pre-encoded payload takes precedence over body, which is JSON-encoded when
+// non-nil. A nil ctx uses the client context.
+type request struct {
+	ctx         context.Context
+	method      string
+	endpoint    string
+	query       string
+	body        any
+	payload     []byte
+	stream      io.Reader
+	contentType string
+	header      http.Header
+}
+
 func (c *Client) doJSON(method, endpoint, rawQuery string, in any, out any) error {
-	u, err := url.Parse(c.baseURL)
+	r := &request{method: method, endpoint: endpoint, query: rawQuery, body: in}
+	if c.cache != nil {
+		if handled, err := c.cache.doJSON(c, r, out); handled {
+			return err
+		}
+	}
+
+	b, err := c.fetch(r)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(b, out)
+}
+
+// fetch performs the request and returns the buffered response body.
+func (c *Client) fetch(r *request) ([]byte, error) {
+	resp, err := c.do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return c.readBody(resp.Body)
+}
+
+// doStream performs the request and hands the successful response body to fn
+// without buffering it, so callers can decode arbitrarily large payloads.
+func (c *Client) doStream(method, endpoint, rawQuery string, in any, fn func(io.Reader) error) error {
+	resp, err := c.do(&request{method: method, endpoint: endpoint, query: rawQuery, body: in})
 	if err != nil {
 		return err
 	}
-	u.Path = path.Join(u.Path, endpoint)
-	if rawQuery != "" {
-		u.RawQuery = rawQuery
+	defer resp.Body.Close()
+	return fn(resp.Body)
+}
+
+// do sends the request through the circuit breaker, when enabled, and returns
+// the response for any 2xx status. The caller owns the response body.
+func (c *Client) do(r *request) (*http.Response, error) {
+	if c.breaker == nil {
+		return c.send(r)
+	}
+	group, err := c.breaker.allow(r.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w (%s)", err, group)
 	}
+	resp, err := c.send(r)
+	switch {
+	case countsAsFailure(err):
+		c.breaker.record(group, err)
+	case serverAnswered(err):
+		c.breaker.record(group, nil)
+	default:
+		// Cancelled or never sent: no verdict on the server either way.
+		c.breaker.release(group)
+	}
+	return resp, err
+}
 
-	var payload []byte
-	if in != nil {
-		payload, err = json.Marshal(in)
+// send performs the request with GET retries and endpoint failover.
+func (c *Client) send(r *request) (*http.Response, error) {
+	method := r.method
+	payload, contentType := r.payload, r.contentType
+	if payload == nil && r.body != nil {
+		var err error
+		payload, err = json.Marshal(r.body)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		contentType = "application/json"
+	}
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = c.ctx
 	}
 
 	attempts := 1
-	if method == http.MethodGet {
+	if method == http.MethodGet && r.stream == nil {
 		attempts = 3
 	}
 	backoff := 150 * time.Millisecond
 
 	for i := 0; i < attempts; i++ {
+		base := c.endpoints.pick(method, r.endpoint)
+		u, err := url.Parse(base)
+		if err != nil {
+			return nil, err
+		}
+		u.Path = path.Join(u.Path, r.endpoint)
+		if r.query != "" {
+			u.RawQuery = r.query
+		}
+
 		var body io.Reader
-		if payload != nil {
+		if r.stream != nil {
+			body = r.stream
+		} else if payload != nil {
 			body = bytes.NewReader(payload)
 		}
 
-		req, err := http.NewRequestWithContext(c.ctx, method, u.String(), body)
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if (payload != nil || r.stream != nil) && contentType != "" {
+			req.Header.Set("Content-Type", contentType)
 		}
-		if payload != nil {
-			req.Header.Set("Content-Type", "application/json")
+		for k, v := range r.header {
+			req.Header[k] = v
 		}
 
-		if tok := c.Token(); tok != "" {
+		if tok := c.Token(); tok != "" && req.Header.Get("Authorization") == "" {
 			req.Header.Set("Authorization", "Bearer "+tok)
 		}
 
 		resp, err := c.http.Do(req)
 		if err != nil {
+			if isTransient(err) {
+				if c.logger != nil {
+					c.logger.Printf("pbclient: endpoint %s marked down (%v)", base, err)
+				}
+				c.endpoints.markDown(base, err)
+			}
 			if method == http.MethodGet && i < attempts-1 && isTransient(err) {
 				if c.logger != nil {
 					c.logger.Printf("pbclient: retry GET %s (%v)", u.String(), err)
@@ -63,12 +161,32 @@ func (c *Client) doJSON(method, endpoint, rawQuery string, in any, out any) erro
 				backoff *= 2
 				continue
 			}
-			return err
+			return nil, err
+		}
+		if resp.StatusCode < 500 {
+			c.endpoints.markUp(base)
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+		if resp.StatusCode == http.StatusNotModified && req.Header.Get("If-None-Match") != "" {
+			return resp, nil
 		}
 
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
 		_ = resp.Body.Close()
 
+		if resp.StatusCode >= 500 {
+			// An answering but failing endpoint (e.g. mid-restore) is skipped
+			// like an unreachable one until its health probe passes again.
+			apiErr := parseAPIError(resp.StatusCode, b)
+			if c.logger != nil {
+				c.logger.Printf("pbclient: endpoint %s marked down (http %d)", base, resp.StatusCode)
+			}
+			c.endpoints.markDown(base, apiErr)
+		}
+
 		if resp.StatusCode >= 500 && resp.StatusCode <= 599 && method == http.MethodGet && i < attempts-1 {
 			if c.logger != nil {
 				c.logger.Printf("pbclient: retry GET %s (http %d)", u.String(), resp.StatusCode)
@@ -78,15 +196,24 @@ func (c *Client) doJSON(method, endpoint, rawQuery string, in any, out any) erro
 			continue
 		}
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return parseAPIError(resp.StatusCode, b)
-		}
-
-		if out == nil {
-			return nil
-		}
-		return json.Unmarshal(b, out)
+		return nil, parseAPIError(resp.StatusCode, b)
 	}
 
-	return fmt.Errorf("pbclient: request failed after retries")
+	return nil, fmt.Errorf("pbclient: request failed after retries")
+}
+
+// readBody reads r fully, failing with ErrResponseTooLarge when it exceeds the
+// configured maximum response size.
+func (c *Client) readBody(r io.Reader) ([]byte, error) {
+	if c.maxResponseSize < 0 {
+		return io.ReadAll(r)
+	}
+	b, err := io.ReadAll(io.LimitReader(r, c.maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > c.maxResponseSize {
+		return nil, fmt.Errorf("%w (limit %d bytes)", ErrResponseTooLarge, c.maxResponseSize)
+	}
+	return b, nil
 }
@@ -0,0 +1,91 @@
+package pbclient
+
+import (
+	"crypto/rand"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// DefaultIDPattern is the pattern of PocketBase's default id field.
+const DefaultIDPattern = `^[a-z0-9]+$`
+
+const idAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// NewID returns a random 15-character [a-z0-9] record ID matching
+// PocketBase's default id field, generated with crypto/rand.
+func NewID() string {
+	const n = 15
+	buf := make([]byte, n)
+	out := make([]byte, n)
+	for i := 0; i < n; {
+		if _, err := rand.Read(buf); err != nil {
+			panic("pbclient: crypto/rand: " + err.Error())
+		}
+		for _, b := range buf {
+			// Reject bytes that would bias the distribution (252 = 7*36).
+			if b >= 252 {
+				continue
+			}
+			out[i] = idAlphabet[int(b)%len(idAlphabet)]
+			i++
+			if i == n {
+				break
+			}
+		}
+	}
+	return string(out)
+}
+
+// ValidateID checks id against the collection's id field definition: its
+// pattern and min/max length. Without an id field it applies the PocketBase
+// defaults (15 characters, [a-z0-9]).
+func ValidateID(col CollectionModel, id string) error {
+	pattern, minLen, maxLen := DefaultIDPattern, 15, 15
+	if f := col.Field("id"); f != nil {
+		if p, ok := f.Options["pattern"].(string); ok {
+			pattern = p
+		}
+		minLen, maxLen = optInt(f.Options["min"]), optInt(f.Options["max"])
+	}
+	if minLen > 0 && len(id) < minLen {
+		return fmt.Errorf("pbclient: id %q for %s is shorter than %d characters", id, col.Name, minLen)
+	}
+	if maxLen > 0 && len(id) > maxLen {
+		return fmt.Errorf("pbclient: id %q for %s is longer than %d characters", id, col.Name, maxLen)
+	}
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("pbclient: invalid id pattern for %s: %w", col.Name, err)
+		}
+		if !re.MatchString(id) {
+			return fmt.Errorf("pbclient: id %q for %s does not match %s", id, col.Name, pattern)
+		}
+	}
+	return nil
+}
+
+// NewIDFor generates an ID with NewID and validates it against the
+// collection schema, so callers learn early when a collection uses a custom id
+// pattern that client-side IDs cannot satisfy.
+func NewIDFor(col CollectionModel) (string, error) {
+	id := NewID()
+	if err := ValidateID(col, id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func optInt(v any) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	case string:
+		i, _ := strconv.Atoi(n)
+		return i
+	}
+	return 0
+}
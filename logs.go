@@ -0,0 +1,160 @@
+package pbclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LogEntry is a PocketBase request/app log entry.
+type LogEntry struct {
+	ID      string         `json:"id"`
+	Created string         `json:"created"`
+	Updated string         `json:"updated"`
+	Level   int            `json:"level"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data"`
+}
+
+// LogStat is one hourly bucket returned by /api/logs/stats.
+type LogStat struct {
+	Total int    `json:"total"`
+	Date  string `json:"date"`
+}
+
+// LogsService reads /api/logs. All operations require superuser auth. Logs
+// are stored per instance, so with Config.BaseURLs they are read from the
+// primary.
+type LogsService struct {
+	c *Client
+}
+
+// Logs returns the logs service.
+func (c *Client) Logs() *LogsService { return &LogsService{c: c} }
+
+// List returns a page of log entries, e.g. List("filter=level>0&sort=-created").
+func (s *LogsService) List(params ...string) (ListResult[LogEntry], error) {
+	var out ListResult[LogEntry]
+	err := s.c.doJSON(http.MethodGet, "/api/logs", optParam(params), nil, &out)
+	return out, err
+}
+
+// Get returns a single log entry.
+func (s *LogsService) Get(id string) (LogEntry, error) {
+	var out LogEntry
+	err := s.c.doJSON(http.MethodGet, "/api/logs/"+url.PathEscape(id), "", nil, &out)
+	return out, err
+}
+
+// Stats returns hourly log counts, optionally narrowed with a filter param.
+func (s *LogsService) Stats(params ...string) ([]LogStat, error) {
+	var out []LogStat
+	err := s.c.doJSON(http.MethodGet, "/api/logs/stats", optParam(params), nil, &out)
+	return out, err
+}
+
+// TailOptions configures LogsService.Tail.
+type TailOptions struct {
+	// Filter is an optional PocketBase filter expression combined with the
+	// created watermark, e.g. "level >= 4".
+	Filter string
+	// Since starts tailing after this time. Zero starts after the newest
+	// existing entry, so only new entries are delivered.
+	Since time.Time
+	// Interval between polls. Defaults to 2s.
+	Interval time.Duration
+	// PerPage bounds each poll. Defaults to 200.
+	PerPage int
+}
+
+// pbDateLayout is the datetime format PocketBase uses in filters and records.
+const pbDateLayout = "2006-01-02 15:04:05.000Z"
+
+// Tail polls /api/logs for entries newer than a watermark on created and
+// passes them to fn in order until ctx is done or fn returns an error.
+func (s *LogsService) Tail(ctx context.Context, opts TailOptions, fn func(LogEntry) error) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = 200
+	}
+
+	// created is not unique, so polls use >= and skip IDs already delivered at
+	// the watermark timestamp.
+	watermark := ""
+	seen := map[string]bool{}
+	if !opts.Since.IsZero() {
+		watermark = opts.Since.UTC().Format(pbDateLayout)
+	} else {
+		q := "sort=-created&perPage=1&skipTotal=1"
+		if opts.Filter != "" {
+			q += "&filter=" + url.QueryEscape(opts.Filter)
+		}
+		latest, err := s.List(q)
+		if err != nil {
+			return err
+		}
+		if len(latest.Items) > 0 {
+			watermark = latest.Items[0].Created
+			seen[latest.Items[0].ID] = true
+		}
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		for {
+			filter := opts.Filter
+			if watermark != "" {
+				cond := "created >= '" + watermark + "'"
+				if filter != "" {
+					filter = "(" + filter + ") && " + cond
+				} else {
+					filter = cond
+				}
+			}
+			q := "sort=created&skipTotal=1&perPage=" + strconv.Itoa(perPage)
+			if filter != "" {
+				q += "&filter=" + url.QueryEscape(filter)
+			}
+			res, err := s.List(q)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return err
+			}
+
+			fresh := 0
+			for _, e := range res.Items {
+				if e.Created == watermark && seen[e.ID] {
+					continue
+				}
+				if err := fn(e); err != nil {
+					return err
+				}
+				fresh++
+				if e.Created != watermark {
+					watermark = e.Created
+					seen = map[string]bool{}
+				}
+				seen[e.ID] = true
+			}
+			if len(res.Items) < perPage || fresh == 0 {
+				break
+			}
+		}
+		timer.Reset(interval)
+	}
+}
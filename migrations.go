@@ -0,0 +1,236 @@
+package pbclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// DefaultMigrationsCollection stores applied migration versions.
+const DefaultMigrationsCollection = "_pbclient_migrations"
+
+// Migration is one versioned schema change. Versions are applied in ascending
+// order; Down is optional and only needed for rollbacks.
+type Migration struct {
+	Version     int64
+	Description string
+	Up          func(m *Migrator) error
+	Down        func(m *Migrator) error
+}
+
+// MigrationRecord is an applied migration as stored on the server.
+type MigrationRecord struct {
+	ID          string `json:"id,omitempty"`
+	Version     int64  `json:"version"`
+	Description string `json:"description"`
+	Applied     string `json:"applied"`
+}
+
+// Migrator applies migrations against a server using a superuser client.
+//
+// In dry-run mode the migration functions still run, but collection changes
+// made through the Migrator helpers are written to Out instead of the server
+// and no versions are recorded.
+type Migrator struct {
+	c          *Client
+	migrations []Migration
+
+	// Collection holds applied versions. Defaults to DefaultMigrationsCollection.
+	Collection string
+	// DryRun prints planned changes instead of applying them.
+	DryRun bool
+	// Out receives dry-run plans and progress lines. Nil discards them.
+	Out io.Writer
+}
+
+// NewMigrator creates a migrator for the given migrations. The client must be
+// authenticated as a superuser.
+func NewMigrator(c *Client, migrations []Migration) *Migrator {
+	if c == nil {
+		c = mustDefault()
+	}
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return &Migrator{c: c, migrations: sorted, Collection: DefaultMigrationsCollection}
+}
+
+// Client returns the client migrations run against, for operations not
+// covered by the Migrator helpers. Calls made through it bypass dry-run.
+func (m *Migrator) Client() *Client { return m.c }
+
+// CreateCollection creates a collection, or prints it in dry-run mode.
+func (m *Migrator) CreateCollection(def CollectionModel) error {
+	if m.DryRun {
+		m.printf("create collection %q (%s)\n", def.Name, def.Type)
+		for _, f := range def.Fields {
+			m.printf("  + field %s %s\n", f.Name, f.Type)
+		}
+		return nil
+	}
+	_, err := m.c.Collections().Create(def)
+	return err
+}
+
+// UpdateCollection patches a collection, or prints it in dry-run mode.
+func (m *Migrator) UpdateCollection(idOrName string, patch any) error {
+	if m.DryRun {
+		b, _ := json.Marshal(patch)
+		m.printf("update collection %q: %s\n", idOrName, b)
+		return nil
+	}
+	_, err := m.c.Collections().Update(idOrName, patch)
+	return err
+}
+
+// DeleteCollection deletes a collection, or prints it in dry-run mode.
+func (m *Migrator) DeleteCollection(idOrName string) error {
+	if m.DryRun {
+		m.printf("delete collection %q\n", idOrName)
+		return nil
+	}
+	return m.c.Collections().Delete(idOrName)
+}
+
+// Applied returns the versions recorded on the server, in ascending order.
+func (m *Migrator) Applied() ([]MigrationRecord, error) {
+	exists, err := m.ensureCollection()
+	if err != nil || !exists {
+		return nil, err
+	}
+	var all []MigrationRecord
+	err = Collection[MigrationRecord](m.Collection, m.c).StreamAll(func(r MigrationRecord) error {
+		all = append(all, r)
+		return nil
+	}, "sort=version&perPage=500")
+	return all, err
+}
+
+// Pending returns migrations not yet applied, in the order Up would run them.
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.appliedSet()
+	if err != nil {
+		return nil, err
+	}
+	var out []Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; !ok {
+			out = append(out, mig)
+		}
+	}
+	return out, nil
+}
+
+// Up applies all pending migrations in version order and stops at the first
+// failure. It returns the versions applied (or planned, in dry-run mode).
+func (m *Migrator) Up() ([]int64, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+	var done []int64
+	for _, mig := range pending {
+		m.printf("up %d %s\n", mig.Version, mig.Description)
+		if mig.Up != nil {
+			if err := mig.Up(m); err != nil {
+				return done, fmt.Errorf("pbclient: migration %d up: %w", mig.Version, err)
+			}
+		}
+		if !m.DryRun {
+			rec := MigrationRecord{Version: mig.Version, Description: mig.Description, Applied: time.Now().UTC().Format(time.RFC3339)}
+			if _, err := Collection[MigrationRecord](m.Collection, m.c).Create(rec); err != nil {
+				return done, fmt.Errorf("pbclient: record migration %d: %w", mig.Version, err)
+			}
+		}
+		done = append(done, mig.Version)
+	}
+	return done, nil
+}
+
+// Down rolls back the most recent applied migrations, newest first. steps <= 0
+// rolls back one.
+func (m *Migrator) Down(steps int) ([]int64, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+	applied, err := m.appliedSet()
+	if err != nil {
+		return nil, err
+	}
+	var done []int64
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		mig := m.migrations[i]
+		rec, ok := applied[mig.Version]
+		if !ok {
+			continue
+		}
+		if mig.Down == nil {
+			return done, fmt.Errorf("pbclient: migration %d has no down", mig.Version)
+		}
+		m.printf("down %d %s\n", mig.Version, mig.Description)
+		if err := mig.Down(m); err != nil {
+			return done, fmt.Errorf("pbclient: migration %d down: %w", mig.Version, err)
+		}
+		if !m.DryRun {
+			if err := Collection[MigrationRecord](m.Collection, m.c).Delete(rec.ID); err != nil {
+				return done, fmt.Errorf("pbclient: unrecord migration %d: %w", mig.Version, err)
+			}
+		}
+		done = append(done, mig.Version)
+	}
+	return done, nil
+}
+
+func (m *Migrator) appliedSet() (map[int64]MigrationRecord, error) {
+	recs, err := m.Applied()
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[int64]MigrationRecord, len(recs))
+	for _, r := range recs {
+		set[r.Version] = r
+	}
+	return set, nil
+}
+
+// ensureCollection creates the tracking collection if it does not exist. In
+// dry-run mode a missing collection is reported instead of created and
+// exists is false.
+func (m *Migrator) ensureCollection() (exists bool, err error) {
+	_, err = m.c.Collections().Get(m.Collection)
+	if err == nil {
+		return true, nil
+	}
+	if !isNotFound(err) {
+		return false, err
+	}
+	if m.DryRun {
+		m.printf("create collection %q (migration tracking)\n", m.Collection)
+		return false, nil
+	}
+	_, err = m.c.Collections().Create(CollectionModel{
+		Name: m.Collection,
+		Type: CollectionTypeBase,
+		Fields: []Field{
+			{Name: "version", Type: FieldNumber, Required: true, Options: map[string]any{"onlyInt": true}},
+			{Name: "description", Type: FieldText},
+			{Name: "applied", Type: FieldText},
+		},
+		Indexes: []string{fmt.Sprintf("CREATE UNIQUE INDEX `idx_%s_version` ON `%s` (`version`)", m.Collection, m.Collection)},
+	})
+	return err == nil, err
+}
+
+func (m *Migrator) printf(format string, args ...any) {
+	if m.Out != nil {
+		fmt.Fprintf(m.Out, format, args...)
+	}
+}
+
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
@@ -21,12 +21,29 @@ type RealtimeEvent struct {
 }
 
 // Realtime manages PocketBase realtime subscriptions over SSE.
+//
+// Topics registered with On are dispatched to their handlers; every other
+// event, including PB_CONNECT, is delivered on the Events channel.
 type Realtime struct {
 	c *Client
 
 	mu            sync.RWMutex
 	clientId      string
 	subscriptions []string
+	handlers      map[string][]*topicHandler
+
+	// postMu serializes subscription updates so the server sees them in order.
+	postMu sync.Mutex
+	// lossy drops Events that would block, for connections nobody drains.
+	lossy bool
+
+	state         RealtimeState
+	lastErr       error
+	attempts      int
+	reconnects    int
+	everConnected bool
+	connectedAt   time.Time
+	onState       func(RealtimeStatus)
 
 	Events chan RealtimeEvent
 
@@ -60,6 +77,7 @@ func (rt *Realtime) ClientID() string {
 func (rt *Realtime) Connect() error {
 	ctx, cancel := context.WithCancel(rt.c.ctx)
 	rt.cancel = cancel
+	rt.setState(RealtimeConnecting, nil)
 
 	rt.wg.Add(1)
 	go func() {
@@ -90,17 +108,131 @@ func (rt *Realtime) Connect() error {
 	}
 }
 
-// Subscribe sets active subscriptions and applies them if already connected.
+type topicHandler struct {
+	fn func(RealtimeEvent)
+}
+
+// Subscribe sets the topics delivered on the Events channel and applies them
+// if already connected. Topics registered with On are kept.
 func (rt *Realtime) Subscribe(subscriptions ...string) error {
 	rt.mu.Lock()
 	rt.subscriptions = append([]string(nil), subscriptions...)
-	cid := rt.clientId
+	rt.mu.Unlock()
+	return rt.resubscribe()
+}
+
+// On registers handler for topic, e.g. "posts/*" or "posts/RECORD_ID", on the
+// shared connection and updates the server subscription set if the topic is
+// new. Use SubscriptionTopic to add filter or expand options to a topic.
+// Handlers run on the connection's read loop and should return quickly.
+// The returned func removes the handler and drops the topic once it has no
+// handlers left.
+func (rt *Realtime) On(topic string, handler func(RealtimeEvent)) (unsubscribe func()) {
+	h := &topicHandler{fn: handler}
+
+	rt.mu.Lock()
+	if rt.handlers == nil {
+		rt.handlers = map[string][]*topicHandler{}
+	}
+	isNew := len(rt.handlers[topic]) == 0
+	rt.handlers[topic] = append(rt.handlers[topic], h)
 	rt.mu.Unlock()
 
+	if isNew {
+		rt.resubscribeAsync()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			rt.mu.Lock()
+			list := rt.handlers[topic]
+			for i, x := range list {
+				if x == h {
+					list = append(list[:i:i], list[i+1:]...)
+					break
+				}
+			}
+			if len(list) == 0 {
+				delete(rt.handlers, topic)
+			} else {
+				rt.handlers[topic] = list
+			}
+			removed := len(list) == 0
+			rt.mu.Unlock()
+			if removed {
+				rt.resubscribeAsync()
+			}
+		})
+	}
+}
+
+// Topics returns the full set of subscribed topics.
+func (rt *Realtime) Topics() []string {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	return rt.topicsLocked()
+}
+
+func (rt *Realtime) topicsLocked() []string {
+	seen := make(map[string]bool, len(rt.subscriptions)+len(rt.handlers))
+	var out []string
+	for _, t := range rt.subscriptions {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	for _, t := range sortedKeys(rt.handlers) {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// resubscribe posts the full topic set when connected.
+func (rt *Realtime) resubscribe() error {
+	_, err := rt.applyTopics()
+	return err
+}
+
+// applyTopics posts the full topic set and reports whether it was posted,
+// which it is not while disconnected.
+func (rt *Realtime) applyTopics() (bool, error) {
+	rt.postMu.Lock()
+	defer rt.postMu.Unlock()
+
+	rt.mu.RLock()
+	cid := rt.clientId
+	topics := rt.topicsLocked()
+	rt.mu.RUnlock()
+
 	if cid == "" {
-		return nil
+		return false, nil
 	}
-	return rt.applySubscriptions(cid, subscriptions)
+	return true, rt.applySubscriptions(cid, topics)
+}
+
+func (rt *Realtime) resubscribeAsync() {
+	go func() {
+		if err := rt.resubscribe(); err != nil && rt.c.logger != nil {
+			rt.c.logger.Printf("pbclient: realtime subscribe: %v", err)
+		}
+	}()
+}
+
+// dispatch calls the handlers registered for ev's topic and reports whether
+// there were any.
+func (rt *Realtime) dispatch(ev RealtimeEvent) bool {
+	rt.mu.RLock()
+	list := append([]*topicHandler(nil), rt.handlers[ev.Event]...)
+	rt.mu.RUnlock()
+	for _, h := range list {
+		h.fn(ev)
+	}
+	return len(list) > 0
 }
 
 // Close stops reconnect loops, waits for shutdown, and closes the Events channel.
@@ -109,6 +241,7 @@ func (rt *Realtime) Close() {
 		rt.cancel()
 	}
 	rt.wg.Wait()
+	rt.setState(RealtimeClosed, nil)
 	close(rt.Events)
 }
 
@@ -117,34 +250,32 @@ func (rt *Realtime) loop(ctx context.Context) {
 	maxBackoff := 5 * time.Second
 
 	for {
-		select {
-		case <-ctx.Done():
+		cid, err := rt.connectOnce(ctx)
+		if ctx.Err() != nil {
 			return
-		default:
 		}
-
-		cid, err := rt.connectOnce(ctx)
-		if err != nil {
-			if !isTransient(err) {
-				rt.Events <- RealtimeEvent{Event: "PB_ERROR", Data: json.RawMessage(strconvJSON(err.Error()))}
-				return
-			}
-			time.Sleep(backoff)
-			backoff *= 2
-			if backoff > maxBackoff {
-				backoff = maxBackoff
+		if cid != "" {
+			// The connection was up; topics are re-applied on the next PB_CONNECT.
+			backoff = 200 * time.Millisecond
+		}
+		if err != nil && !isTransient(err) {
+			rt.setState(RealtimeClosed, err)
+			select {
+			case rt.Events <- RealtimeEvent{Event: "PB_ERROR", Data: json.RawMessage(strconvJSON(err.Error()))}:
+			case <-ctx.Done():
 			}
-			continue
+			return
 		}
+		rt.setState(RealtimeReconnecting, err)
 
-		backoff = 200 * time.Millisecond
-
-		rt.mu.RLock()
-		subs := append([]string(nil), rt.subscriptions...)
-		rt.mu.RUnlock()
-
-		if len(subs) > 0 {
-			_ = rt.applySubscriptions(cid, subs)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
 		}
 	}
 }
@@ -174,6 +305,12 @@ func (rt *Realtime) connectOnce(ctx context.Context) (string, error) {
 
 	defer resp.Body.Close()
 
+	defer func() {
+		rt.mu.Lock()
+		rt.clientId = ""
+		rt.mu.Unlock()
+	}()
+
 	br := bufio.NewReader(resp.Body)
 	cid := ""
 	for {
@@ -199,12 +336,31 @@ func (rt *Realtime) connectOnce(ctx context.Context) (string, error) {
 				rt.mu.Lock()
 				rt.clientId = cid
 				rt.mu.Unlock()
+				rt.setState(RealtimeConnected, nil)
+				// (Re)apply the full topic set for the new client ID without
+				// stalling the read loop.
+				rt.resubscribeAsync()
 				rt.errOnce.Do(func() { close(rt.readyCh) })
 			}
 		}
 
+		event := RealtimeEvent{Event: ev, Data: append(json.RawMessage(nil), data...)}
+		if rt.c.cache != nil {
+			rt.c.cache.invalidateEvent(event)
+		}
+		if rt.dispatch(event) {
+			continue
+		}
+		if rt.lossy {
+			select {
+			case rt.Events <- event:
+			default:
+			}
+			continue
+		}
+
 		select {
-		case rt.Events <- RealtimeEvent{Event: ev, Data: append(json.RawMessage(nil), data...)}:
+		case rt.Events <- event:
 		case <-ctx.Done():
 			return cid, ctx.Err()
 		}
@@ -247,3 +403,43 @@ func strconvJSON(s string) []byte {
 	b, _ := json.Marshal(map[string]string{"error": s})
 	return b
 }
+
+// ErrRealtimeNotConnected is returned when a subscription cannot be applied
+// because the realtime connection is down.
+var ErrRealtimeNotConnected = errors.New("pbclient: realtime not connected")
+
+// Realtime returns the client's shared realtime connection, connecting it on
+// first use and again after it has closed for good, e.g. on a non-transient
+// error. Typed subscriptions such as Coll[T].Subscribe multiplex their topics
+// onto it via On. Events without a handler are delivered on its Events
+// channel on a best-effort basis and dropped when the buffer is full.
+func (c *Client) Realtime() (*Realtime, error) {
+	c.rtMu.Lock()
+	defer c.rtMu.Unlock()
+	if c.rt != nil {
+		if c.rt.State() != RealtimeClosed {
+			return c.rt, nil
+		}
+		c.rt.Close()
+		c.rt = nil
+	}
+	rt := NewRealtime(c)
+	rt.lossy = true
+	if err := rt.Connect(); err != nil {
+		rt.Close()
+		return nil, err
+	}
+	c.rt = rt
+	return rt, nil
+}
+
+// Close releases the client's shared realtime connection, if one was opened.
+func (c *Client) Close() {
+	c.rtMu.Lock()
+	rt := c.rt
+	c.rt = nil
+	c.rtMu.Unlock()
+	if rt != nil {
+		rt.Close()
+	}
+}
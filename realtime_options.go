@@ -0,0 +1,66 @@
+package pbclient
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// SubscribeOptions narrows and shapes the records a realtime topic delivers.
+// Query uses the same raw format as List params, e.g.
+// "filter=status='published'&expand=author"; Filter, Expand and Fields
+// override the matching keys in it.
+type SubscribeOptions struct {
+	Query   string
+	Filter  string
+	Expand  string
+	Fields  string
+	Headers map[string]string
+}
+
+func (o SubscribeOptions) empty() bool {
+	return o.Query == "" && o.Filter == "" && o.Expand == "" && o.Fields == "" && len(o.Headers) == 0
+}
+
+// SubscriptionTopic returns topic with opts encoded as the PocketBase
+// "options" query parameter, e.g. `posts/*?options={"query":{...}}`. The
+// result is deterministic, so it also matches the event names the server
+// sends for the subscription.
+func SubscriptionTopic(topic string, opts SubscribeOptions) (string, error) {
+	if opts.empty() {
+		return topic, nil
+	}
+	query := map[string]string{}
+	if q := joinQuery(opts.Query); q != "" {
+		vals, err := url.ParseQuery(q)
+		if err != nil {
+			return "", err
+		}
+		for k, v := range vals {
+			if len(v) > 0 {
+				query[k] = v[len(v)-1]
+			}
+		}
+	}
+	if opts.Filter != "" {
+		query["filter"] = opts.Filter
+	}
+	if opts.Expand != "" {
+		query["expand"] = opts.Expand
+	}
+	if opts.Fields != "" {
+		query["fields"] = opts.Fields
+	}
+
+	payload := map[string]any{}
+	if len(query) > 0 {
+		payload["query"] = query
+	}
+	if len(opts.Headers) > 0 {
+		payload["headers"] = opts.Headers
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return topic + "?options=" + url.QueryEscape(string(b)), nil
+}
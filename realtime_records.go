@@ -0,0 +1,81 @@
+package pbclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RecordAction is the kind of change reported by a record realtime event.
+type RecordAction string
+
+const (
+	ActionCreate RecordAction = "create"
+	ActionUpdate RecordAction = "update"
+	ActionDelete RecordAction = "delete"
+)
+
+// RecordEvent is a decoded record subscription event.
+type RecordEvent[T any] struct {
+	Topic  string
+	Action RecordAction
+	Record T
+}
+
+// DecodeRecordEvent decodes the {action, record} payload of a raw realtime
+// event from a record topic.
+func DecodeRecordEvent[T any](ev RealtimeEvent) (RecordEvent[T], error) {
+	var payload struct {
+		Action RecordAction `json:"action"`
+		Record T            `json:"record"`
+	}
+	if err := json.Unmarshal(ev.Data, &payload); err != nil {
+		return RecordEvent[T]{}, fmt.Errorf("pbclient: decode realtime event %s: %w", ev.Event, err)
+	}
+	return RecordEvent[T]{Topic: ev.Event, Action: payload.Action, Record: payload.Record}, nil
+}
+
+// Subscribe listens for changes to the collection on the client's shared
+// realtime connection and calls handler with decoded events until ctx is done.
+// topic is "*" for all records or a record ID. The collection's default query
+// and the optional opts shape the delivered records, e.g. to filter or expand
+// them. It returns once the subscription has been applied, or
+// ErrRealtimeNotConnected if the connection is down, e.g. while reconnecting.
+// Events that fail to decode are skipped.
+func (col *Coll[T]) Subscribe(ctx context.Context, topic string, handler func(RecordEvent[T]), opts ...SubscribeOptions) error {
+	if topic == "" {
+		topic = "*"
+	}
+	var o SubscribeOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.Query = mergeQuery(col.query, joinQuery(o.Query))
+	full, err := SubscriptionTopic(col.name+"/"+topic, o)
+	if err != nil {
+		return err
+	}
+
+	rt, err := col.c.Realtime()
+	if err != nil {
+		return err
+	}
+	unsubscribe := rt.On(full, func(ev RealtimeEvent) {
+		if rec, err := DecodeRecordEvent[T](ev); err == nil {
+			handler(rec)
+		}
+	})
+	posted, err := rt.applyTopics()
+	if err == nil && !posted {
+		err = fmt.Errorf("%w (%s)", ErrRealtimeNotConnected, rt.State())
+	}
+	if err != nil {
+		unsubscribe()
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+	return nil
+}
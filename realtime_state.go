@@ -0,0 +1,120 @@
+package pbclient
+
+import "time"
+
+// RealtimeState is the connection state of a Realtime client.
+type RealtimeState int
+
+const (
+	// RealtimeClosed is the state before Connect, after Close, and after a
+	// non-recoverable error.
+	RealtimeClosed RealtimeState = iota
+	// RealtimeConnecting is the initial connection attempt.
+	RealtimeConnecting
+	// RealtimeConnected means the SSE stream is up and a client ID assigned.
+	RealtimeConnected
+	// RealtimeReconnecting means the stream dropped and a retry is pending.
+	RealtimeReconnecting
+)
+
+func (s RealtimeState) String() string {
+	switch s {
+	case RealtimeClosed:
+		return "closed"
+	case RealtimeConnecting:
+		return "connecting"
+	case RealtimeConnected:
+		return "connected"
+	case RealtimeReconnecting:
+		return "reconnecting"
+	}
+	return "unknown"
+}
+
+// RealtimeStatus is a snapshot of a Realtime connection.
+type RealtimeStatus struct {
+	State    RealtimeState
+	ClientID string
+	// LastError is the most recent connection error, kept after recovery.
+	LastError error
+	// Attempts counts consecutive failed attempts since the last connect.
+	Attempts int
+	// Reconnects counts successful reconnects after the first connect.
+	Reconnects  int
+	ConnectedAt time.Time
+}
+
+// State returns the current connection state.
+func (rt *Realtime) State() RealtimeState {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	return rt.state
+}
+
+// Status returns a snapshot of the connection state, client ID, last error
+// and reconnect counters, for readiness checks.
+func (rt *Realtime) Status() RealtimeStatus {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	return rt.statusLocked()
+}
+
+// LastError returns the most recent connection error, if any.
+func (rt *Realtime) LastError() error {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	return rt.lastErr
+}
+
+// OnStateChange registers fn to be called with a status snapshot on every
+// state change and on each failed reconnect attempt. It runs on the
+// connection goroutine and should return quickly. Register it before Connect
+// to observe the initial transitions.
+func (rt *Realtime) OnStateChange(fn func(RealtimeStatus)) {
+	rt.mu.Lock()
+	rt.onState = fn
+	rt.mu.Unlock()
+}
+
+func (rt *Realtime) statusLocked() RealtimeStatus {
+	return RealtimeStatus{
+		State:       rt.state,
+		ClientID:    rt.clientId,
+		LastError:   rt.lastErr,
+		Attempts:    rt.attempts,
+		Reconnects:  rt.reconnects,
+		ConnectedAt: rt.connectedAt,
+	}
+}
+
+// setState moves the connection to state, updating counters, and notifies
+// the OnStateChange callback.
+func (rt *Realtime) setState(state RealtimeState, err error) {
+	rt.mu.Lock()
+	prev := rt.state
+	switch state {
+	case RealtimeConnected:
+		if rt.everConnected {
+			rt.reconnects++
+		}
+		rt.everConnected = true
+		rt.attempts = 0
+		rt.connectedAt = time.Now()
+	case RealtimeReconnecting:
+		rt.attempts++
+		rt.lastErr = err
+	case RealtimeClosed:
+		rt.clientId = ""
+		if err != nil {
+			rt.lastErr = err
+		}
+	}
+	rt.state = state
+	st := rt.statusLocked()
+	fn := rt.onState
+	rt.mu.Unlock()
+
+	if fn != nil && (prev != state || state == RealtimeReconnecting) {
+		fn(st)
+	}
+}
@@ -0,0 +1,281 @@
+package pbclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Collection types.
+const (
+	CollectionTypeBase = "base"
+	CollectionTypeAuth = "auth"
+	CollectionTypeView = "view"
+)
+
+// Field types.
+const (
+	FieldText     = "text"
+	FieldEditor   = "editor"
+	FieldNumber   = "number"
+	FieldBool     = "bool"
+	FieldEmail    = "email"
+	FieldURL      = "url"
+	FieldDate     = "date"
+	FieldAutodate = "autodate"
+	FieldSelect   = "select"
+	FieldFile     = "file"
+	FieldRelation = "relation"
+	FieldJSON     = "json"
+	FieldPassword = "password"
+	FieldGeoPoint = "geoPoint"
+)
+
+// Field is a collection field definition (PocketBase v0.23+ schema).
+// Type-specific options not modelled explicitly round-trip through Options.
+type Field struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	System      bool   `json:"system,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty"`
+	Presentable bool   `json:"presentable,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+
+	// Select fields.
+	Values []string `json:"values,omitempty"`
+	// Select, relation and file fields; values above 1 hold multiple entries.
+	MaxSelect int `json:"maxSelect,omitempty"`
+	// Relation fields.
+	CollectionID  string `json:"collectionId,omitempty"`
+	CascadeDelete bool   `json:"cascadeDelete,omitempty"`
+
+	Options map[string]any `json:"-"`
+}
+
+type fieldAlias Field
+
+// MarshalJSON flattens Options into the field object as PocketBase expects.
+func (f Field) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(fieldAlias(f), f.Options)
+}
+
+// UnmarshalJSON collects unknown keys into Options.
+func (f *Field) UnmarshalJSON(b []byte) error {
+	var a fieldAlias
+	extra, err := unmarshalWithExtra(b, &a)
+	if err != nil {
+		return err
+	}
+	*f = Field(a)
+	f.Options = extra
+	return nil
+}
+
+// CollectionModel is a PocketBase collection definition. Rules are nil for
+// superuser-only access and "" for public access. Auth-specific settings such
+// as passwordAuth or oauth2 round-trip through Options.
+type CollectionModel struct {
+	ID         string   `json:"id,omitempty"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	System     bool     `json:"system,omitempty"`
+	Fields     []Field  `json:"fields"`
+	Indexes    []string `json:"indexes"`
+	ListRule   *string  `json:"listRule"`
+	ViewRule   *string  `json:"viewRule"`
+	CreateRule *string  `json:"createRule"`
+	UpdateRule *string  `json:"updateRule"`
+	DeleteRule *string  `json:"deleteRule"`
+	ViewQuery  string   `json:"viewQuery,omitempty"`
+	Created    string   `json:"created,omitempty"`
+	Updated    string   `json:"updated,omitempty"`
+
+	Options map[string]any `json:"-"`
+}
+
+type collectionAlias CollectionModel
+
+// MarshalJSON flattens Options into the collection object.
+func (m CollectionModel) MarshalJSON() ([]byte, error) {
+	if m.Fields == nil {
+		m.Fields = []Field{}
+	}
+	if m.Indexes == nil {
+		m.Indexes = []string{}
+	}
+	return marshalWithExtra(collectionAlias(m), m.Options)
+}
+
+// UnmarshalJSON collects unknown keys into Options.
+func (m *CollectionModel) UnmarshalJSON(b []byte) error {
+	var a collectionAlias
+	extra, err := unmarshalWithExtra(b, &a)
+	if err != nil {
+		return err
+	}
+	*m = CollectionModel(a)
+	m.Options = extra
+	return nil
+}
+
+// Field returns the field with the given name, or nil.
+func (m *CollectionModel) Field(name string) *Field {
+	for i := range m.Fields {
+		if m.Fields[i].Name == name {
+			return &m.Fields[i]
+		}
+	}
+	return nil
+}
+
+// GeoPoint is the value of a geoPoint field.
+type GeoPoint struct {
+	Lon float64 `json:"lon"`
+	Lat float64 `json:"lat"`
+}
+
+// Rule returns a pointer to rule for use in CollectionModel rule fields.
+func Rule(rule string) *string { return &rule }
+
+// CollectionsService manages collection schemas via /api/collections.
+// All operations require superuser auth.
+type CollectionsService struct {
+	c *Client
+}
+
+// Collections returns the collections admin service.
+func (c *Client) Collections() *CollectionsService { return &CollectionsService{c: c} }
+
+// Collections returns the collections admin service for the default client.
+func Collections() *CollectionsService { return mustDefault().Collections() }
+
+// List returns a page of collections.
+func (s *CollectionsService) List(params ...string) (ListResult[CollectionModel], error) {
+	var out ListResult[CollectionModel]
+	err := s.c.doJSON(http.MethodGet, "/api/collections", optParam(params), nil, &out)
+	return out, err
+}
+
+// FullList returns every collection, walking all pages.
+func (s *CollectionsService) FullList(params ...string) ([]CollectionModel, error) {
+	q := withParam(optParam(params), "perPage", "200")
+	var all []CollectionModel
+	for page := 1; ; page++ {
+		res, err := s.List(withParam(q, "page", strconv.Itoa(page)))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, res.Items...)
+		if len(res.Items) == 0 || page >= res.TotalPages {
+			return all, nil
+		}
+	}
+}
+
+// Get returns a collection by ID or name.
+func (s *CollectionsService) Get(idOrName string, params ...string) (CollectionModel, error) {
+	var out CollectionModel
+	err := s.c.doJSON(http.MethodGet, "/api/collections/"+url.PathEscape(idOrName), optParam(params), nil, &out)
+	return out, err
+}
+
+// Create creates a new collection.
+func (s *CollectionsService) Create(m CollectionModel, params ...string) (CollectionModel, error) {
+	var out CollectionModel
+	err := s.c.doJSON(http.MethodPost, "/api/collections", optParam(params), m, &out)
+	return out, err
+}
+
+// Update patches a collection by ID or name. Pass a CollectionModel to replace
+// the definition or a map for a partial update.
+func (s *CollectionsService) Update(idOrName string, patch any, params ...string) (CollectionModel, error) {
+	var out CollectionModel
+	err := s.c.doJSON(http.MethodPatch, "/api/collections/"+url.PathEscape(idOrName), optParam(params), patch, &out)
+	return out, err
+}
+
+// Delete removes a collection by ID or name.
+func (s *CollectionsService) Delete(idOrName string) error {
+	return s.c.doJSON(http.MethodDelete, "/api/collections/"+url.PathEscape(idOrName), "", nil, nil)
+}
+
+// Truncate deletes all records of a collection, including cascade deletes.
+func (s *CollectionsService) Truncate(idOrName string) error {
+	err := s.c.doJSON(http.MethodDelete, "/api/collections/"+url.PathEscape(idOrName)+"/truncate", "", nil, nil)
+	if err == nil {
+		s.c.InvalidateCache(idOrName)
+	}
+	return err
+}
+
+// Import bulk-imports collection definitions. When deleteMissing is true,
+// collections and fields not present in the import are deleted.
+func (s *CollectionsService) Import(collections []CollectionModel, deleteMissing bool) error {
+	body := map[string]any{"collections": collections, "deleteMissing": deleteMissing}
+	err := s.c.doJSON(http.MethodPut, "/api/collections/import", "", body, nil)
+	if err == nil {
+		s.c.InvalidateCache()
+	}
+	return err
+}
+
+// marshalWithExtra marshals v and merges extra keys that v does not set.
+func marshalWithExtra(v any, extra map[string]any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	for k, val := range extra {
+		if _, ok := m[k]; ok {
+			continue
+		}
+		raw, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		m[k] = raw
+	}
+	return json.Marshal(m)
+}
+
+// unmarshalWithExtra decodes b into v and returns the keys v does not know.
+func unmarshalWithExtra(b []byte, v any) (map[string]any, error) {
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, err
+	}
+	var all map[string]any
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, err
+	}
+	for _, k := range jsonFieldNames(v) {
+		delete(all, k)
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+// jsonFieldNames lists the JSON keys of the struct v points to.
+func jsonFieldNames(v any) []string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,292 @@
+package pbclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// LoadSnapshot reads a collections snapshot as exported from the PocketBase
+// dashboard (Settings > Export collections), which is a JSON array.
+func LoadSnapshot(r io.Reader) ([]CollectionModel, error) {
+	var out []CollectionModel
+	if err := json.NewDecoder(r).Decode(&out); err != nil {
+		return nil, fmt.Errorf("pbclient: decode snapshot: %w", err)
+	}
+	return out, nil
+}
+
+// LoadSnapshotFile reads a collections snapshot from a file.
+func LoadSnapshotFile(name string) ([]CollectionModel, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadSnapshot(f)
+}
+
+// SchemaDiff describes how an actual schema differs from an expected one.
+// Collections and fields are matched by name.
+type SchemaDiff struct {
+	// Added lists collections present only in the actual schema.
+	Added []CollectionModel
+	// Removed lists collections present only in the expected schema.
+	Removed []CollectionModel
+	// Changed lists collections present in both with differences.
+	Changed []CollectionDiff
+}
+
+// CollectionDiff describes changes to one collection.
+type CollectionDiff struct {
+	Name           string
+	TypeFrom       string
+	TypeTo         string
+	AddedFields    []Field
+	RemovedFields  []Field
+	ChangedFields  []FieldChange
+	RuleChanges    []RuleChange
+	AddedIndexes   []string
+	RemovedIndexes []string
+	ViewQueryFrom  string
+	ViewQueryTo    string
+}
+
+// FieldChange is a field whose definition differs.
+type FieldChange struct {
+	Name string
+	From Field
+	To   Field
+}
+
+// RuleChange is an API rule whose value differs. Nil means superuser only.
+type RuleChange struct {
+	Rule string
+	From *string
+	To   *string
+}
+
+// Empty reports whether the schemas match.
+func (d SchemaDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffCollections compares an expected schema (for example a snapshot) with an
+// actual one (for example the live server).
+func DiffCollections(expected, actual []CollectionModel) SchemaDiff {
+	var d SchemaDiff
+	exp := indexCollections(expected)
+	act := indexCollections(actual)
+
+	for _, name := range sortedKeys(act) {
+		if _, ok := exp[name]; !ok {
+			d.Added = append(d.Added, act[name])
+		}
+	}
+	for _, name := range sortedKeys(exp) {
+		a, ok := act[name]
+		if !ok {
+			d.Removed = append(d.Removed, exp[name])
+			continue
+		}
+		if cd, changed := diffCollection(exp[name], a); changed {
+			d.Changed = append(d.Changed, cd)
+		}
+	}
+	return d
+}
+
+// Diff compares the live server schema against an expected snapshot.
+func (s *CollectionsService) Diff(expected []CollectionModel) (SchemaDiff, error) {
+	live, err := s.FullList()
+	if err != nil {
+		return SchemaDiff{}, err
+	}
+	return DiffCollections(expected, live), nil
+}
+
+func diffCollection(from, to CollectionModel) (CollectionDiff, bool) {
+	cd := CollectionDiff{Name: from.Name}
+	changed := false
+
+	if from.Type != to.Type {
+		cd.TypeFrom, cd.TypeTo = from.Type, to.Type
+		changed = true
+	}
+	if from.ViewQuery != to.ViewQuery {
+		cd.ViewQueryFrom, cd.ViewQueryTo = from.ViewQuery, to.ViewQuery
+		changed = true
+	}
+
+	ff := indexFields(from.Fields)
+	tf := indexFields(to.Fields)
+	for _, f := range to.Fields {
+		if _, ok := ff[f.Name]; !ok {
+			cd.AddedFields = append(cd.AddedFields, f)
+		}
+	}
+	for _, f := range from.Fields {
+		t, ok := tf[f.Name]
+		if !ok {
+			cd.RemovedFields = append(cd.RemovedFields, f)
+			continue
+		}
+		if !fieldsEqual(f, t) {
+			cd.ChangedFields = append(cd.ChangedFields, FieldChange{Name: f.Name, From: f, To: t})
+		}
+	}
+
+	rules := []struct {
+		name     string
+		from, to *string
+	}{
+		{"listRule", from.ListRule, to.ListRule},
+		{"viewRule", from.ViewRule, to.ViewRule},
+		{"createRule", from.CreateRule, to.CreateRule},
+		{"updateRule", from.UpdateRule, to.UpdateRule},
+		{"deleteRule", from.DeleteRule, to.DeleteRule},
+	}
+	if from.Type == CollectionTypeAuth || to.Type == CollectionTypeAuth {
+		// Auth-only access rules are not modelled and live in Options.
+		for _, name := range []string{"authRule", "manageRule"} {
+			rules = append(rules, struct {
+				name     string
+				from, to *string
+			}{name, optionRule(from.Options, name), optionRule(to.Options, name)})
+		}
+	}
+	for _, r := range rules {
+		if !rulesEqual(r.from, r.to) {
+			cd.RuleChanges = append(cd.RuleChanges, RuleChange{Rule: r.name, From: r.from, To: r.to})
+		}
+	}
+
+	cd.AddedIndexes, cd.RemovedIndexes = diffStrings(from.Indexes, to.Indexes)
+
+	changed = changed || len(cd.AddedFields) > 0 || len(cd.RemovedFields) > 0 || len(cd.ChangedFields) > 0 ||
+		len(cd.RuleChanges) > 0 || len(cd.AddedIndexes) > 0 || len(cd.RemovedIndexes) > 0
+	return cd, changed
+}
+
+// fieldsEqual compares field definitions ignoring their IDs.
+func fieldsEqual(a, b Field) bool {
+	a.ID, b.ID = "", ""
+	ab, _ := json.Marshal(a)
+	bb, _ := json.Marshal(b)
+	return bytes.Equal(ab, bb)
+}
+
+// optionRule reads a rule kept in Options. Missing and null rules are nil.
+func optionRule(opts map[string]any, key string) *string {
+	if s, ok := opts[key].(string); ok {
+		return &s
+	}
+	return nil
+}
+
+func rulesEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func diffStrings(from, to []string) (added, removed []string) {
+	fs := make(map[string]bool, len(from))
+	for _, s := range from {
+		fs[s] = true
+	}
+	ts := make(map[string]bool, len(to))
+	for _, s := range to {
+		ts[s] = true
+		if !fs[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range from {
+		if !ts[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+func indexCollections(list []CollectionModel) map[string]CollectionModel {
+	m := make(map[string]CollectionModel, len(list))
+	for _, c := range list {
+		m[c.Name] = c
+	}
+	return m
+}
+
+func indexFields(list []Field) map[string]Field {
+	m := make(map[string]Field, len(list))
+	for _, f := range list {
+		m[f.Name] = f
+	}
+	return m
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String renders the diff as a human-readable report.
+func (d SchemaDiff) String() string {
+	if d.Empty() {
+		return "no schema changes\n"
+	}
+	var b strings.Builder
+	for _, c := range d.Added {
+		fmt.Fprintf(&b, "+ collection %s (%s)\n", c.Name, c.Type)
+	}
+	for _, c := range d.Removed {
+		fmt.Fprintf(&b, "- collection %s (%s)\n", c.Name, c.Type)
+	}
+	for _, c := range d.Changed {
+		fmt.Fprintf(&b, "~ collection %s\n", c.Name)
+		if c.TypeFrom != c.TypeTo {
+			fmt.Fprintf(&b, "    type: %s -> %s\n", c.TypeFrom, c.TypeTo)
+		}
+		if c.ViewQueryFrom != c.ViewQueryTo {
+			fmt.Fprintf(&b, "    viewQuery: %q -> %q\n", c.ViewQueryFrom, c.ViewQueryTo)
+		}
+		for _, f := range c.AddedFields {
+			fmt.Fprintf(&b, "    + field %s (%s)\n", f.Name, f.Type)
+		}
+		for _, f := range c.RemovedFields {
+			fmt.Fprintf(&b, "    - field %s (%s)\n", f.Name, f.Type)
+		}
+		for _, f := range c.ChangedFields {
+			from, _ := json.Marshal(f.From)
+			to, _ := json.Marshal(f.To)
+			fmt.Fprintf(&b, "    ~ field %s\n        from: %s\n        to:   %s\n", f.Name, from, to)
+		}
+		for _, r := range c.RuleChanges {
+			fmt.Fprintf(&b, "    ~ %s: %s -> %s\n", r.Rule, formatRule(r.From), formatRule(r.To))
+		}
+		for _, idx := range c.AddedIndexes {
+			fmt.Fprintf(&b, "    + index %s\n", idx)
+		}
+		for _, idx := range c.RemovedIndexes {
+			fmt.Fprintf(&b, "    - index %s\n", idx)
+		}
+	}
+	return b.String()
+}
+
+func formatRule(r *string) string {
+	if r == nil {
+		return "null (superusers only)"
+	}
+	return fmt.Sprintf("%q", *r)
+}
@@ -0,0 +1,134 @@
+package pbclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// File is a file attachment sent as a multipart/form-data field.
+type File struct {
+	Name        string
+	ContentType string
+	Reader      io.Reader
+}
+
+// SendOptions configures a Send call.
+type SendOptions struct {
+	// Query is a raw query string, e.g. "filter=...&expand=author".
+	Query string
+	// Headers are added to the request. Authorization is set from the client
+	// token unless provided here.
+	Headers http.Header
+	// Body is JSON-encoded, or sent as the @jsonPayload field when Files is set.
+	Body any
+	// Files switches the request to multipart/form-data, one entry per field.
+	Files map[string][]File
+}
+
+// Send calls an arbitrary PocketBase route, such as a custom route registered
+// with routerAdd, using the client's auth, retries, failover, circuit breaker
+// and error parsing. It returns the raw response body.
+func (c *Client) Send(ctx context.Context, method, path string, opts SendOptions) (json.RawMessage, error) {
+	r := &request{ctx: ctx, method: method, endpoint: "/" + strings.TrimLeft(path, "/"), query: joinQuery(opts.Query), header: opts.Headers}
+	if len(opts.Files) > 0 {
+		payload, contentType, err := encodeMultipart(opts.Body, opts.Files)
+		if err != nil {
+			return nil, err
+		}
+		r.payload, r.contentType = payload, contentType
+	} else {
+		r.body = opts.Body
+	}
+	b, err := c.fetch(r)
+	if c.cache != nil {
+		c.cache.invalidateWrite(method, r.endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(b), nil
+}
+
+// Send calls an arbitrary route using the default client.
+func Send(ctx context.Context, method, path string, opts SendOptions) (json.RawMessage, error) {
+	return mustDefault().Send(ctx, method, path, opts)
+}
+
+// SendAs calls an arbitrary route like Client.Send and decodes the JSON
+// response into T. A nil client uses the default client.
+func SendAs[T any](ctx context.Context, c *Client, method, path string, opts SendOptions) (T, error) {
+	var out T
+	if c == nil {
+		c = mustDefault()
+	}
+	b, err := c.Send(ctx, method, path, opts)
+	if err != nil {
+		return out, err
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return out, nil
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return out, fmt.Errorf("pbclient: decode %s %s: %w", method, path, err)
+	}
+	return out, nil
+}
+
+// encodeMultipart builds a multipart/form-data body with body marshalled into
+// the @jsonPayload field followed by the given files.
+func encodeMultipart(body any, files map[string][]File) ([]byte, string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := mw.WriteField("@jsonPayload", string(payload)); err != nil {
+			return nil, "", err
+		}
+	}
+	for field, list := range files {
+		for _, f := range list {
+			if err := writeFilePart(mw, field, f); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), mw.FormDataContentType(), nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func writeFilePart(mw *multipart.Writer, field string, f File) error {
+	if f.Reader == nil {
+		return fmt.Errorf("pbclient: file %q for field %q has no reader", f.Name, field)
+	}
+	name := f.Name
+	if name == "" {
+		name = "file"
+	}
+	ct := f.ContentType
+	if ct == "" {
+		ct = "application/octet-stream"
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(field), quoteEscaper.Replace(name)))
+	h.Set("Content-Type", ct)
+	w, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f.Reader)
+	return err
+}
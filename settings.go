@@ -0,0 +1,161 @@
+package pbclient
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Settings holds the PocketBase application settings. Sections are pointers so
+// Update only patches the sections that are set.
+type Settings struct {
+	Meta         *MetaSettings         `json:"meta,omitempty"`
+	Logs         *LogsSettings         `json:"logs,omitempty"`
+	Backups      *BackupsSettings      `json:"backups,omitempty"`
+	SMTP         *SMTPSettings         `json:"smtp,omitempty"`
+	S3           *S3Settings           `json:"s3,omitempty"`
+	Batch        *BatchSettings        `json:"batch,omitempty"`
+	RateLimits   *RateLimitsSettings   `json:"rateLimits,omitempty"`
+	TrustedProxy *TrustedProxySettings `json:"trustedProxy,omitempty"`
+}
+
+type MetaSettings struct {
+	AppName       string `json:"appName"`
+	AppURL        string `json:"appURL"`
+	SenderName    string `json:"senderName"`
+	SenderAddress string `json:"senderAddress"`
+	HideControls  bool   `json:"hideControls"`
+}
+
+type LogsSettings struct {
+	MaxDays   int  `json:"maxDays"`
+	MinLevel  int  `json:"minLevel"`
+	LogIP     bool `json:"logIP"`
+	LogAuthID bool `json:"logAuthId"`
+}
+
+type BackupsSettings struct {
+	Cron        string     `json:"cron"`
+	CronMaxKeep int        `json:"cronMaxKeep"`
+	S3          S3Settings `json:"s3"`
+}
+
+// SMTPSettings configures outgoing mail. The password is not returned by Get;
+// leave it empty on Update to keep the stored one.
+type SMTPSettings struct {
+	Enabled    bool   `json:"enabled"`
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	Username   string `json:"username"`
+	Password   string `json:"password,omitempty"`
+	AuthMethod string `json:"authMethod"`
+	TLS        bool   `json:"tls"`
+	LocalName  string `json:"localName"`
+}
+
+// S3Settings configures an S3-compatible storage. The secret is not returned
+// by Get; leave it empty on Update to keep the stored one.
+type S3Settings struct {
+	Enabled        bool   `json:"enabled"`
+	Bucket         string `json:"bucket"`
+	Region         string `json:"region"`
+	Endpoint       string `json:"endpoint"`
+	AccessKey      string `json:"accessKey"`
+	Secret         string `json:"secret,omitempty"`
+	ForcePathStyle bool   `json:"forcePathStyle"`
+}
+
+type BatchSettings struct {
+	Enabled     bool  `json:"enabled"`
+	MaxRequests int   `json:"maxRequests"`
+	Timeout     int64 `json:"timeout"`
+	MaxBodySize int64 `json:"maxBodySize"`
+}
+
+type RateLimitsSettings struct {
+	Enabled bool            `json:"enabled"`
+	Rules   []RateLimitRule `json:"rules"`
+}
+
+type RateLimitRule struct {
+	Label       string `json:"label"`
+	Audience    string `json:"audience,omitempty"`
+	Duration    int64  `json:"duration"`
+	MaxRequests int    `json:"maxRequests"`
+}
+
+type TrustedProxySettings struct {
+	Headers       []string `json:"headers"`
+	UseLeftmostIP bool     `json:"useLeftmostIP"`
+}
+
+// Filesystems accepted by SettingsService.TestS3.
+const (
+	FilesystemStorage = "storage"
+	FilesystemBackups = "backups"
+)
+
+// SettingsService reads and updates /api/settings. All operations require
+// superuser auth.
+type SettingsService struct {
+	c *Client
+}
+
+// Settings returns the settings service.
+func (c *Client) Settings() *SettingsService { return &SettingsService{c: c} }
+
+// Get returns the current settings.
+func (s *SettingsService) Get() (Settings, error) {
+	var out Settings
+	err := s.c.doJSON(http.MethodGet, "/api/settings", "", nil, &out)
+	return out, err
+}
+
+// Update patches the settings sections set in patch and returns the result.
+func (s *SettingsService) Update(patch Settings) (Settings, error) {
+	var out Settings
+	err := s.c.doJSON(http.MethodPatch, "/api/settings", "", patch, &out)
+	return out, err
+}
+
+// TestS3 checks the S3 connection for FilesystemStorage or FilesystemBackups.
+func (s *SettingsService) TestS3(filesystem string) error {
+	if filesystem == "" {
+		filesystem = FilesystemStorage
+	}
+	return s.c.doJSON(http.MethodPost, "/api/settings/test/s3", "", map[string]any{"filesystem": filesystem}, nil)
+}
+
+// TestEmail sends a test email using the given template, e.g. "verification",
+// "password-reset", "email-change", "otp" or "login-alert". If collection is
+// empty, it defaults to "_superusers".
+func (s *SettingsService) TestEmail(collection, toEmail, template string) error {
+	if collection == "" {
+		collection = "_superusers"
+	}
+	body := map[string]any{"email": toEmail, "template": template, "collection": collection}
+	return s.c.doJSON(http.MethodPost, "/api/settings/test/email", "", body, nil)
+}
+
+// AppleClientSecretRequest holds the inputs for generating a Sign in with
+// Apple client secret. Duration is in seconds.
+type AppleClientSecretRequest struct {
+	ClientID   string `json:"clientId"`
+	TeamID     string `json:"teamId"`
+	KeyID      string `json:"keyId"`
+	PrivateKey string `json:"privateKey"`
+	Duration   int64  `json:"duration"`
+}
+
+// GenerateAppleClientSecret generates a Sign in with Apple client secret JWT.
+func (s *SettingsService) GenerateAppleClientSecret(req AppleClientSecretRequest) (string, error) {
+	var out struct {
+		Secret string `json:"secret"`
+	}
+	if err := s.c.doJSON(http.MethodPost, "/api/settings/apple/generate-client-secret", "", req, &out); err != nil {
+		return "", err
+	}
+	if out.Secret == "" {
+		return "", fmt.Errorf("pbclient: empty apple client secret")
+	}
+	return out.Secret, nil
+}
@@ -0,0 +1,86 @@
+package pbclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const superusersCollection = "_superusers"
+
+// Superuser is a record of the _superusers system collection.
+type Superuser struct {
+	ID       string `json:"id,omitempty"`
+	Email    string `json:"email"`
+	Verified bool   `json:"verified"`
+	Created  string `json:"created,omitempty"`
+	Updated  string `json:"updated,omitempty"`
+}
+
+// SuperusersService manages superuser accounts (PocketBase v0.23+). All
+// operations except Bootstrap require superuser auth.
+type SuperusersService struct {
+	c *Client
+}
+
+// Superusers returns the superusers service.
+func (c *Client) Superusers() *SuperusersService { return &SuperusersService{c: c} }
+
+func (s *SuperusersService) coll() *Coll[Superuser] {
+	return Collection[Superuser](superusersCollection, s.c)
+}
+
+// List returns a page of superusers.
+func (s *SuperusersService) List(params ...string) (ListResult[Superuser], error) {
+	return s.coll().List(params...)
+}
+
+// Create adds a superuser with the given credentials.
+func (s *SuperusersService) Create(email, password string) (Superuser, error) {
+	return s.coll().Create(superuserBody(email, password))
+}
+
+// SetPassword rotates the password of the superuser with the given ID.
+func (s *SuperusersService) SetPassword(id, password string) (Superuser, error) {
+	return s.coll().Update(id, map[string]any{"password": password, "passwordConfirm": password})
+}
+
+// Delete removes the superuser with the given ID. PocketBase refuses to delete
+// the last remaining superuser.
+func (s *SuperusersService) Delete(id string) error {
+	return s.coll().Delete(id)
+}
+
+// Bootstrap creates the first superuser on a fresh instance using the
+// installer token PocketBase prints on first start (the token itself or the
+// full "/_/#/pbinstal/<token>" URL), then logs the client in as that superuser.
+// It fails once any superuser exists, since the installer token is then void.
+func (s *SuperusersService) Bootstrap(installer, email, password string) error {
+	token := installer
+	if _, after, ok := strings.Cut(installer, "pbinstal/"); ok {
+		token = after
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return errors.New("pbclient: empty installer token")
+	}
+
+	_, err := s.c.Send(s.c.ctx, http.MethodPost, "/api/collections/"+superusersCollection+"/records", SendOptions{
+		Headers: http.Header{"Authorization": {"Bearer " + token}},
+		Body:    superuserBody(email, password),
+	})
+	if err != nil {
+		return fmt.Errorf("pbclient: bootstrap superuser: %w", err)
+	}
+	return s.c.LoginSuperAdmin(email, password)
+}
+
+func superuserBody(email, password string) map[string]any {
+	return map[string]any{
+		"email":           email,
+		"password":        password,
+		"passwordConfirm": password,
+		"verified":        true,
+	}
+}
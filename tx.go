@@ -0,0 +1,90 @@
+package pbclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// TxAbortedError wraps the error returned by a Tx closure. Result handles of
+// operations queued in an aborted transaction report it.
+type TxAbortedError struct{ Err error }
+
+func (e *TxAbortedError) Error() string { return "pbclient: transaction aborted: " + e.Err.Error() }
+
+func (e *TxAbortedError) Unwrap() error { return e.Err }
+
+// Tx collects operations queued by fn and sends them as one atomic /api/batch
+// call only if fn returns nil. When fn fails nothing is sent, so no writes
+// happen, and the error is returned as *TxAbortedError. If the server rejects
+// the batch, PocketBase rolls back every operation.
+//
+// Use the result handles returned while queuing to read decoded records, and
+// CreateWithID to reference records created earlier in the same transaction.
+func (c *Client) Tx(ctx context.Context, fn func(tx *Batch) error) ([]BatchResponse, error) {
+	if ctx == nil {
+		ctx = c.ctx
+	}
+	tx := NewBatch(c)
+	if err := fn(tx); err != nil {
+		aborted := &TxAbortedError{Err: err}
+		resolveBatch(tx.results, nil, aborted)
+		return nil, aborted
+	}
+	if len(tx.requests) == 0 {
+		return nil, nil
+	}
+	return tx.post(ctx, "", tx.requests, tx.results)
+}
+
+// Tx runs a transaction using the default client.
+func Tx(ctx context.Context, fn func(tx *Batch) error) ([]BatchResponse, error) {
+	return mustDefault().Tx(ctx, fn)
+}
+
+// CreateWithID enqueues a create with a client-generated record ID and returns
+// that ID, so later operations in the same batch can reference the new record.
+// An "id" already present in body is kept.
+func (bc *TypedBatchCollection[T]) CreateWithID(body any, params ...string) (string, *BatchResult[T]) {
+	m, err := bodyMap(body)
+	if err != nil {
+		r := &BatchResult[T]{}
+		r.resolve(0, nil, err)
+		return "", r
+	}
+	id, _ := m["id"].(string)
+	if id == "" {
+		id = NewID()
+		m["id"] = id
+	}
+	return id, bc.Create(m, params...)
+}
+
+// CreateWithID enqueues a create with a client-generated record ID.
+func (bc *BatchCollection) CreateWithID(body any, params ...string) (string, *BatchResult[map[string]any]) {
+	return BatchCollectionOf[map[string]any](bc.b, bc.name).CreateWithID(body, params...)
+}
+
+// bodyMap returns body as a fresh map, converting structs through JSON. File
+// values in map bodies are preserved.
+func bodyMap(body any) (map[string]any, error) {
+	if m, ok := body.(map[string]any); ok {
+		cp := make(map[string]any, len(m)+1)
+		for k, v := range m {
+			cp[k] = v
+		}
+		return cp, nil
+	}
+	out := map[string]any{}
+	if body == nil {
+		return out, nil
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("pbclient: body must encode to a JSON object: %w", err)
+	}
+	return out, nil
+}